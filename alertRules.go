@@ -0,0 +1,425 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Tipos de reglas de alerta
+const (
+	AlertDownDuration = "down_duration" // caído durante más de Minutes minutos
+	AlertFailures     = "failures"      // Failures fallos en los últimos Checks checks
+	AlertLatencyP95   = "latency_p95"   // p95 del tiempo de respuesta > ThresholdMs en WindowMinutes
+	AlertCertExpiry   = "cert_expiry"   // certificado expira en menos de Days días
+	AlertUptimeSLO    = "uptime_slo"    // uptime < SLOPercent en WindowMinutes
+)
+
+// Regla de alerta configurable en config.json
+type AlertRule struct {
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	Enabled  bool     `json:"enabled"`
+	Sites    []string `json:"sites,omitempty"`  // vacío = todos los sitios
+	Groups   []string `json:"groups,omitempty"` // vacío = todos los grupos
+	Channels []string `json:"channels"`         // canales de notificación por nombre
+
+	Minutes       int     `json:"minutes,omitempty"`
+	Failures      int     `json:"failures,omitempty"`
+	Checks        int     `json:"checks,omitempty"`
+	ThresholdMs   int64   `json:"thresholdMs,omitempty"`
+	WindowMinutes int     `json:"windowMinutes,omitempty"`
+	Days          int     `json:"days,omitempty"`
+	SLOPercent    float64 `json:"sloPercent,omitempty"`
+}
+
+// Información de la alerta incluida en el NotificationEvent
+type AlertInfo struct {
+	Rule    string  `json:"rule"`
+	Type    string  `json:"type"`
+	Message string  `json:"message"`
+	Value   float64 `json:"value"`
+}
+
+// Estado persistido de una regla para un sitio
+type AlertState struct {
+	Rule       string     `json:"rule"`
+	SiteName   string     `json:"siteName"`
+	State      string     `json:"state"` // "firing", "resolved"
+	Value      float64    `json:"value"`
+	Message    string     `json:"message"`
+	FiredAt    *time.Time `json:"firedAt,omitempty"`
+	ResolvedAt *time.Time `json:"resolvedAt,omitempty"`
+}
+
+func (r AlertRule) appliesTo(site Site) bool {
+	return NotificationChannel{Sites: r.Sites, Groups: r.Groups}.appliesTo(site)
+}
+
+// validateAlertRules verifica tipos, parámetros y canales de las reglas
+func validateAlertRules(rules []AlertRule, channels []NotificationChannel) error {
+	names := make(map[string]bool)
+	for _, rule := range rules {
+		if rule.Name == "" {
+			return fmt.Errorf("regla de alerta sin nombre")
+		}
+		if names[rule.Name] {
+			return fmt.Errorf("regla de alerta '%s' duplicada", rule.Name)
+		}
+		names[rule.Name] = true
+
+		switch rule.Type {
+		case AlertDownDuration:
+			if rule.Minutes <= 0 {
+				return fmt.Errorf("regla '%s': minutes debe ser mayor que 0", rule.Name)
+			}
+		case AlertFailures:
+			if rule.Failures <= 0 || rule.Checks < rule.Failures {
+				return fmt.Errorf("regla '%s': se requiere 0 < failures <= checks", rule.Name)
+			}
+		case AlertLatencyP95:
+			if rule.ThresholdMs <= 0 || rule.WindowMinutes <= 0 {
+				return fmt.Errorf("regla '%s': thresholdMs y windowMinutes deben ser mayores que 0", rule.Name)
+			}
+		case AlertCertExpiry:
+			if rule.Days <= 0 {
+				return fmt.Errorf("regla '%s': days debe ser mayor que 0", rule.Name)
+			}
+		case AlertUptimeSLO:
+			if rule.SLOPercent <= 0 || rule.SLOPercent > 100 || rule.WindowMinutes <= 0 {
+				return fmt.Errorf("regla '%s': se requiere 0 < sloPercent <= 100 y windowMinutes > 0", rule.Name)
+			}
+		default:
+			return fmt.Errorf("regla '%s': tipo desconocido '%s'", rule.Name, rule.Type)
+		}
+
+		for _, name := range rule.Channels {
+			found := false
+			for _, channel := range channels {
+				if channel.Name == name {
+					found = true
+					break
+				}
+			}
+			if !found {
+				log.Printf("Regla '%s': canal de notificación '%s' no encontrado", rule.Name, name)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *StatusPageService) initAlertRulesDB() error {
+	createTableSQL := `
+	CREATE TABLE IF NOT EXISTS alert_states (
+		rule_name TEXT NOT NULL,
+		site_name TEXT NOT NULL,
+		state TEXT NOT NULL,
+		value REAL,
+		message TEXT,
+		fired_at DATETIME,
+		resolved_at DATETIME,
+		PRIMARY KEY (rule_name, site_name)
+	);
+	`
+
+	_, err := s.db.Exec(createTableSQL)
+	return err
+}
+
+// evaluateAlertRules evalúa las reglas que aplican al sitio después de guardar
+// un check y notifica los cambios entre "firing" y "resolved"
+func (s *StatusPageService) evaluateAlertRules(site Site, check StatusCheck) {
+	s.alertsMu.Lock()
+	defer s.alertsMu.Unlock()
+
+	for _, rule := range s.currentConfig().AlertRules {
+		if !rule.Enabled || !rule.appliesTo(site) {
+			continue
+		}
+
+		firing, value, message, err := s.evaluateRule(rule, site)
+		if err != nil {
+			log.Printf("Error evaluando regla '%s' para %s: %v", rule.Name, site.Name, err)
+			continue
+		}
+
+		state, err := s.getAlertState(rule.Name, site.Name)
+		if err != nil {
+			log.Printf("Error obteniendo estado de la regla '%s' para %s: %v", rule.Name, site.Name, err)
+			continue
+		}
+
+		wasFiring := state != nil && state.State == "firing"
+		if firing == wasFiring {
+			continue
+		}
+
+		now := time.Now().UTC()
+		newState := AlertState{Rule: rule.Name, SiteName: site.Name, Value: value, Message: message}
+		eventType := EventAlertFiring
+		if firing {
+			newState.State = "firing"
+			newState.FiredAt = &now
+		} else {
+			newState.State = "resolved"
+			newState.FiredAt = state.FiredAt
+			newState.ResolvedAt = &now
+			eventType = EventAlertResolved
+		}
+
+		if err := s.saveAlertState(newState); err != nil {
+			log.Printf("Error guardando estado de la regla '%s' para %s: %v", rule.Name, site.Name, err)
+			continue
+		}
+
+		event := NotificationEvent{
+			Type:  eventType,
+			Site:  site,
+			Check: check,
+			Alert: &AlertInfo{
+				Rule:    rule.Name,
+				Type:    rule.Type,
+				Message: message,
+				Value:   value,
+			},
+			OccurredAt: now,
+		}
+		if incident, err := s.openIncident(site.Name); err == nil && incident != nil {
+			event.Incident = *incident
+		}
+
+		log.Printf("Regla de alerta: %s", event.Summary())
+		s.notifyChannels(rule.Channels, event)
+	}
+}
+
+// evaluateRule calcula si la regla está activa para el sitio, junto con el
+// valor medido y un mensaje descriptivo
+func (s *StatusPageService) evaluateRule(rule AlertRule, site Site) (bool, float64, string, error) {
+	switch rule.Type {
+	case AlertDownDuration:
+		incident, err := s.openIncident(site.Name)
+		if err != nil {
+			return false, 0, "", err
+		}
+		if incident == nil {
+			return false, 0, "el sitio está operativo", nil
+		}
+		minutes := incident.Duration().Minutes()
+		return minutes >= float64(rule.Minutes), minutes,
+			fmt.Sprintf("caído durante %s (límite %d min)", incident.Duration(), rule.Minutes), nil
+
+	case AlertFailures:
+		rows, err := s.db.Query(`
+		SELECT status FROM status_checks
+		WHERE site_name = ? AND status IN ('up', 'down')
+		ORDER BY checked_at DESC, id DESC
+		LIMIT ?`, site.Name, rule.Checks)
+		if err != nil {
+			return false, 0, "", err
+		}
+		defer rows.Close()
+
+		failures := 0
+		for rows.Next() {
+			var status string
+			if err := rows.Scan(&status); err != nil {
+				return false, 0, "", err
+			}
+			if status == "down" {
+				failures++
+			}
+		}
+		return failures >= rule.Failures, float64(failures),
+			fmt.Sprintf("%d fallos en los últimos %d checks (límite %d)", failures, rule.Checks, rule.Failures), rows.Err()
+
+	case AlertLatencyP95:
+		rows, err := s.db.Query(`
+		SELECT response_time FROM status_checks
+		WHERE site_name = ? AND status = 'up' AND checked_at >= datetime('now', ?)`,
+			site.Name, fmt.Sprintf("-%d minutes", rule.WindowMinutes))
+		if err != nil {
+			return false, 0, "", err
+		}
+		defer rows.Close()
+
+		var times []int64
+		for rows.Next() {
+			var rt int64
+			if err := rows.Scan(&rt); err != nil {
+				return false, 0, "", err
+			}
+			times = append(times, rt)
+		}
+		if len(times) == 0 {
+			return false, 0, "sin datos de latencia", rows.Err()
+		}
+		p95 := percentile(times, 95)
+		return p95 > rule.ThresholdMs, float64(p95),
+			fmt.Sprintf("p95 de %d ms en %d min (límite %d ms)", p95, rule.WindowMinutes, rule.ThresholdMs), rows.Err()
+
+	case AlertCertExpiry:
+		var expiresAt sql.NullTime
+		err := s.db.QueryRow(`
+		SELECT cert_expires_at FROM status_checks
+		WHERE site_name = ? AND cert_expires_at IS NOT NULL
+		ORDER BY checked_at DESC, id DESC
+		LIMIT 1`, site.Name).Scan(&expiresAt)
+		if err == sql.ErrNoRows || (err == nil && !expiresAt.Valid) {
+			return false, 0, "sin certificado TLS", nil
+		}
+		if err != nil {
+			return false, 0, "", err
+		}
+		days := time.Until(expiresAt.Time).Hours() / 24
+		return days < float64(rule.Days), math.Floor(days),
+			fmt.Sprintf("el certificado expira el %s (%.0f días)", expiresAt.Time.Format("2006-01-02"), math.Floor(days)), nil
+
+	case AlertUptimeSLO:
+		var up, total sql.NullInt64
+		err := s.db.QueryRow(`
+		SELECT SUM(CASE WHEN status = 'up' THEN 1 ELSE 0 END),
+			   SUM(CASE WHEN status IN ('up', 'down') THEN 1 ELSE 0 END)
+		FROM status_checks
+		WHERE site_name = ? AND checked_at >= datetime('now', ?)`,
+			site.Name, fmt.Sprintf("-%d minutes", rule.WindowMinutes)).Scan(&up, &total)
+		if err != nil {
+			return false, 0, "", err
+		}
+		if total.Int64 == 0 {
+			return false, 0, "sin checks en la ventana", nil
+		}
+		uptime := float64(up.Int64) / float64(total.Int64) * 100
+		return uptime < rule.SLOPercent, uptime,
+			fmt.Sprintf("uptime de %.2f%% en %d min (SLO %.2f%%)", uptime, rule.WindowMinutes, rule.SLOPercent), nil
+	}
+
+	return false, 0, "", fmt.Errorf("tipo de regla desconocido '%s'", rule.Type)
+}
+
+// percentile devuelve el percentil p (0-100) de los valores por el método nearest-rank
+func percentile(values []int64, p float64) int64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]int64(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+const alertStateColumns = `rule_name, site_name, state, value, message, fired_at, resolved_at`
+
+func scanAlertState(row interface{ Scan(...any) error }) (AlertState, error) {
+	var state AlertState
+	var firedAt, resolvedAt sql.NullTime
+	var value sql.NullFloat64
+	var message sql.NullString
+
+	err := row.Scan(&state.Rule, &state.SiteName, &state.State, &value, &message, &firedAt, &resolvedAt)
+	if err != nil {
+		return state, err
+	}
+
+	state.Value = value.Float64
+	state.Message = message.String
+	state.FiredAt = nullTimePtr(firedAt)
+	state.ResolvedAt = nullTimePtr(resolvedAt)
+	return state, nil
+}
+
+func (s *StatusPageService) getAlertState(ruleName, siteName string) (*AlertState, error) {
+	row := s.db.QueryRow(`SELECT `+alertStateColumns+` FROM alert_states
+	WHERE rule_name = ? AND site_name = ?`, ruleName, siteName)
+	state, err := scanAlertState(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+func (s *StatusPageService) saveAlertState(state AlertState) error {
+	_, err := s.db.Exec(`
+	INSERT INTO alert_states (rule_name, site_name, state, value, message, fired_at, resolved_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?)
+	ON CONFLICT(rule_name, site_name) DO UPDATE SET
+		state = excluded.state,
+		value = excluded.value,
+		message = excluded.message,
+		fired_at = excluded.fired_at,
+		resolved_at = excluded.resolved_at`,
+		state.Rule, state.SiteName, state.State, state.Value, state.Message,
+		nullableTime(state.FiredAt), nullableTime(state.ResolvedAt))
+	return err
+}
+
+func nullableTime(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return t.UTC()
+}
+
+// GetAlertStates devuelve el estado de todas las reglas evaluadas
+func (s *StatusPageService) GetAlertStates() ([]AlertState, error) {
+	rows, err := s.db.Query(`SELECT ` + alertStateColumns + ` FROM alert_states ORDER BY rule_name, site_name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var states []AlertState
+	for rows.Next() {
+		state, err := scanAlertState(rows)
+		if err != nil {
+			return nil, err
+		}
+		states = append(states, state)
+	}
+	return states, rows.Err()
+}
+
+// UpdateAlertRules reemplaza las reglas de alerta después de validarlas
+func (s *StatusPageService) UpdateAlertRules(rules []AlertRule) error {
+	s.alertsMu.Lock()
+	err := s.updateConfig(func(c *Config) error {
+		if err := validateAlertRules(rules, c.Notifications); err != nil {
+			return err
+		}
+		c.AlertRules = rules
+		return nil
+	})
+	s.alertsMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	// Eliminar el estado de reglas que ya no existen
+	query := `DELETE FROM alert_states`
+	var args []any
+	if len(rules) > 0 {
+		placeholders := make([]string, len(rules))
+		for i, rule := range rules {
+			placeholders[i] = "?"
+			args = append(args, rule.Name)
+		}
+		query += ` WHERE rule_name NOT IN (` + strings.Join(placeholders, ", ") + `)`
+	}
+	if _, err := s.db.Exec(query, args...); err != nil {
+		return fmt.Errorf("error eliminando el estado de reglas eliminadas: %w", err)
+	}
+
+	return nil
+}
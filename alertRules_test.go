@@ -0,0 +1,173 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// insertCheck guarda un check de hace ago, con checked_at en el formato de CURRENT_TIMESTAMP
+func insertCheck(t *testing.T, s *StatusPageService, site, status string, responseTime int64, ago time.Duration, certExpiresAt *time.Time) {
+	t.Helper()
+	checkedAt := time.Now().UTC().Add(-ago).Format("2006-01-02 15:04:05")
+	_, err := s.db.Exec(`INSERT INTO status_checks (site_name, site_url, status, status_code, response_time, checked_at, cert_expires_at)
+		VALUES (?, 'https://api.example.com', ?, 200, ?, ?, ?)`, site, status, responseTime, checkedAt, nullableTime(certExpiresAt))
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// insertIncident abre un incidente iniciado hace ago
+func insertIncident(t *testing.T, s *StatusPageService, site string, ago time.Duration) {
+	t.Helper()
+	if _, err := s.db.Exec(`INSERT INTO incidents (site_name, started_at) VALUES (?, ?)`, site, time.Now().UTC().Add(-ago)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEvaluateRule(t *testing.T) {
+	inDays := func(days float64) *time.Time {
+		at := time.Now().Add(time.Duration(days * 24 * float64(time.Hour)))
+		return &at
+	}
+	checks := func(statuses ...string) func(t *testing.T, s *StatusPageService) {
+		return func(t *testing.T, s *StatusPageService) {
+			// El primer estado es el más reciente
+			for i, status := range statuses {
+				insertCheck(t, s, "api", status, 100, time.Duration(i+1)*time.Minute, nil)
+			}
+		}
+	}
+	latencies := func(ms ...int64) func(t *testing.T, s *StatusPageService) {
+		return func(t *testing.T, s *StatusPageService) {
+			for i, rt := range ms {
+				insertCheck(t, s, "api", "up", rt, time.Duration(i+1)*time.Minute, nil)
+			}
+		}
+	}
+
+	downDuration := AlertRule{Type: AlertDownDuration, Minutes: 10}
+	failures := AlertRule{Type: AlertFailures, Failures: 3, Checks: 5}
+	latency := AlertRule{Type: AlertLatencyP95, ThresholdMs: 500, WindowMinutes: 60}
+	certExpiry := AlertRule{Type: AlertCertExpiry, Days: 14}
+	uptimeSLO := AlertRule{Type: AlertUptimeSLO, SLOPercent: 99, WindowMinutes: 60}
+
+	tests := []struct {
+		name   string
+		rule   AlertRule
+		seed   func(t *testing.T, s *StatusPageService)
+		firing bool
+		value  float64 // parte entera del valor medido
+	}{
+		{"caído más del límite", downDuration, func(t *testing.T, s *StatusPageService) { insertIncident(t, s, "api", 20*time.Minute) }, true, 20},
+		{"caído menos del límite", downDuration, func(t *testing.T, s *StatusPageService) { insertIncident(t, s, "api", 5*time.Minute) }, false, 5},
+		{"operativo", downDuration, checks("up"), false, 0},
+
+		{"fallos en la ventana", failures, checks("down", "up", "down", "down", "up", "down"), true, 3},
+		{"fallos fuera de la ventana", failures, checks("up", "up", "up", "down", "down", "down"), false, 2},
+		{"no alcanzables no cuentan", failures, checks("unreachable", "unreachable", "down", "up", "down", "up", "up"), false, 2},
+
+		{"p95 sobre el límite", latency, latencies(100, 100, 100, 100, 100, 100, 100, 100, 100, 900), true, 900},
+		{"p95 bajo el límite", latency, latencies(100, 200, 300, 400), false, 400},
+		{"latencia fuera de la ventana", latency, func(t *testing.T, s *StatusPageService) {
+			insertCheck(t, s, "api", "up", 5000, 2*time.Hour, nil)
+			insertCheck(t, s, "api", "up", 100, time.Minute, nil)
+		}, false, 100},
+		{"sin datos de latencia", latency, checks("down"), false, 0},
+
+		{"certificado por expirar", certExpiry, func(t *testing.T, s *StatusPageService) {
+			insertCheck(t, s, "api", "up", 100, 2*time.Minute, inDays(5.5))
+			insertCheck(t, s, "api", "down", 0, time.Minute, nil)
+		}, true, 5},
+		{"certificado vigente", certExpiry, func(t *testing.T, s *StatusPageService) {
+			insertCheck(t, s, "api", "up", 100, time.Minute, inDays(60.5))
+		}, false, 60},
+		{"sin certificado", certExpiry, checks("up"), false, 0},
+
+		{"uptime bajo el SLO", uptimeSLO, checks("up", "up", "up", "down", "up", "up", "up", "up", "up", "up"), true, 90},
+		{"uptime en el SLO", uptimeSLO, checks("up", "unreachable", "up"), false, 100},
+		{"sin checks en la ventana", uptimeSLO, func(t *testing.T, s *StatusPageService) {
+			insertCheck(t, s, "api", "down", 0, 2*time.Hour, nil)
+		}, false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(t)
+			tt.seed(t, s)
+
+			firing, value, message, err := s.evaluateRule(tt.rule, Site{Name: "api"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if firing != tt.firing || math.Floor(value) != tt.value {
+				t.Errorf("activa = %v, valor = %v (%s), se esperaba %v, %v", firing, value, message, tt.firing, tt.value)
+			}
+		})
+	}
+
+	if _, _, _, err := newTestService(t).evaluateRule(AlertRule{Type: "desconocido"}, Site{Name: "api"}); err == nil {
+		t.Error("se esperaba un error para un tipo de regla desconocido")
+	}
+}
+
+// Las reglas pasan de "firing" a "resolved" una sola vez, guardan el estado en
+// alert_states y notifican cada cambio
+func TestAlertRuleTransitions(t *testing.T) {
+	s := newTestService(t)
+	rec := newWebhookRecorder(t)
+	site := Site{Name: "api", URL: "https://api.example.com", Method: "GET", Timeout: 5}
+	err := s.updateConfig(func(c *Config) error {
+		c.Sites = []Site{site}
+		c.Notifications = []NotificationChannel{{Name: "hook", Type: "webhook", Enabled: true, RoutedOnly: true,
+			Webhook: &WebhookConfig{URL: rec.URL}}}
+		c.AlertRules = []AlertRule{{Name: "fallos", Type: AlertFailures, Enabled: true, Failures: 2, Checks: 2, Channels: []string{"hook"}}}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	evaluate := func(status string) []webhookPayload {
+		insertCheck(t, s, "api", status, 100, 0, nil)
+		s.evaluateAlertRules(site, StatusCheck{SiteName: "api", Status: status})
+		s.inFlight.Wait()
+		return rec.take()
+	}
+
+	if sent := evaluate("down"); len(sent) != 0 {
+		t.Fatalf("un solo fallo no debe activar la regla: %+v", sent)
+	}
+	sent := evaluate("down")
+	if len(sent) != 1 || sent[0].Event != EventAlertFiring || sent[0].Alert == nil || sent[0].Alert.Rule != "fallos" {
+		t.Fatalf("notificaciones = %+v", sent)
+	}
+	state, err := s.getAlertState("fallos", "api")
+	if err != nil || state == nil || state.State != "firing" || state.FiredAt == nil || state.ResolvedAt != nil || state.Value != 2 {
+		t.Fatalf("estado = %+v, %v", state, err)
+	}
+	firedAt := *state.FiredAt
+
+	if sent := evaluate("down"); len(sent) != 0 {
+		t.Errorf("una regla activa no debe notificar de nuevo: %+v", sent)
+	}
+
+	if sent = evaluate("up"); len(sent) != 1 || sent[0].Event != EventAlertResolved {
+		t.Fatalf("notificaciones = %+v", sent)
+	}
+	states, err := s.GetAlertStates()
+	if err != nil || len(states) != 1 {
+		t.Fatalf("estados = %+v, %v", states, err)
+	}
+	if state := states[0]; state.State != "resolved" || state.ResolvedAt == nil || state.FiredAt == nil || !state.FiredAt.Equal(firedAt) {
+		t.Errorf("estado = %+v", state)
+	}
+
+	// Al eliminar la regla se elimina su estado
+	if err := s.UpdateAlertRules(nil); err != nil {
+		t.Fatal(err)
+	}
+	if states, err := s.GetAlertStates(); err != nil || len(states) != 0 {
+		t.Errorf("estados = %+v, %v", states, err)
+	}
+}
@@ -0,0 +1,521 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Paginación por defecto y máxima de los listados de la API
+const (
+	defaultAPIPerPage = 50
+	maxAPIPerPage     = 500
+)
+
+type apiPagination struct {
+	Page       int `json:"page"`
+	PerPage    int `json:"perPage"`
+	Total      int `json:"total"`
+	TotalPages int `json:"totalPages"`
+}
+
+type apiResponse struct {
+	Data       any            `json:"data"`
+	Pagination *apiPagination `json:"pagination,omitempty"`
+}
+
+type apiError struct {
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+}
+
+// Cuerpo de POST /api/v1/sites
+type apiSiteRequest struct {
+	Name    string `json:"name"`
+	URL     string `json:"url"`
+	Method  string `json:"method"`
+	Timeout int    `json:"timeout"`
+}
+
+// Cuerpo de PUT/PATCH /api/v1/config; los campos omitidos no se modifican
+type apiConfigRequest struct {
+	CheckInterval *int `json:"checkInterval"`
+	RetentionDays *int `json:"retentionDays"`
+}
+
+// Configuración expuesta por la API (sin credenciales de los canales)
+type apiConfig struct {
+	CheckInterval int `json:"checkInterval"`
+	RetentionDays int `json:"retentionDays"`
+	Sites         int `json:"sites"`
+}
+
+// registerAPIRoutes registra las rutas de la API; todas requieren un token
+// con alcance "read" para lecturas y "write" o "admin" para modificaciones
+func (s *StatusPageService) registerAPIRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("/api/v1/status", s.authorize(ScopeRead, ScopeWrite, s.apiStatus))
+	mux.HandleFunc("/api/v1/stats", s.authorize(ScopeRead, ScopeWrite, s.apiStats))
+	mux.HandleFunc("/api/v1/config", s.authorize(ScopeRead, ScopeAdmin, s.apiConfig))
+	mux.HandleFunc("/api/v1/sites", s.authorize(ScopeRead, ScopeWrite, s.apiSites))
+	mux.HandleFunc("/api/v1/sites/{name}", s.authorize(ScopeRead, ScopeWrite, s.apiSite))
+	mux.HandleFunc("/api/v1/sites/{name}/checks", s.authorize(ScopeRead, ScopeWrite, s.apiSiteChecks))
+	mux.HandleFunc("/api/v1/export", s.authorize(ScopeRead, ScopeRead, s.apiExport))
+	mux.HandleFunc("/api/v1/maintenance", s.authorize(ScopeRead, ScopeWrite, s.apiMaintenances))
+	mux.HandleFunc("/api/v1/maintenance/{id}", s.authorize(ScopeRead, ScopeWrite, s.apiMaintenance))
+	mux.HandleFunc("/api/v1/manual-incidents", s.authorize(ScopeRead, ScopeWrite, s.apiManualIncidents))
+	mux.HandleFunc("/api/v1/manual-incidents/{id}/updates", s.authorize(ScopeRead, ScopeWrite, s.apiManualIncidentUpdates))
+	mux.HandleFunc("/api/v1/events", s.authorizeEvents(s.apiEvents))
+	mux.HandleFunc("/api/v1/tokens", s.authorize(ScopeAdmin, ScopeAdmin, s.apiTokens))
+	mux.HandleFunc("/api/v1/tokens/{id}", s.authorize(ScopeAdmin, ScopeAdmin, s.apiToken))
+	mux.HandleFunc("/api/v1/", func(w http.ResponseWriter, r *http.Request) {
+		writeAPIError(w, http.StatusNotFound, "ruta no encontrada")
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Printf("Error escribiendo respuesta JSON: %v", err)
+	}
+}
+
+func writeAPIError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]apiError{"error": {Status: status, Message: message}})
+}
+
+// allowMethods responde 405 si el método de la petición no está permitido
+func allowMethods(w http.ResponseWriter, r *http.Request, methods ...string) bool {
+	for _, method := range methods {
+		if r.Method == method {
+			return true
+		}
+	}
+	w.Header().Set("Allow", strings.Join(methods, ", "))
+	writeAPIError(w, http.StatusMethodNotAllowed, fmt.Sprintf("método %s no permitido", r.Method))
+	return false
+}
+
+// decodeJSON lee el cuerpo JSON de la petición rechazando campos desconocidos
+func decodeJSON(w http.ResponseWriter, r *http.Request, dst any) bool {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(dst); err != nil {
+		writeAPIError(w, http.StatusBadRequest, "JSON inválido: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// parsePagination lee los parámetros page y perPage de la query
+func parsePagination(r *http.Request) (page, perPage int, err error) {
+	page, perPage = 1, defaultAPIPerPage
+	if v := r.URL.Query().Get("page"); v != "" {
+		if page, err = strconv.Atoi(v); err != nil || page < 1 {
+			return 0, 0, fmt.Errorf("page debe ser un entero mayor que 0")
+		}
+	}
+	if v := r.URL.Query().Get("perPage"); v != "" {
+		if perPage, err = strconv.Atoi(v); err != nil || perPage < 1 || perPage > maxAPIPerPage {
+			return 0, 0, fmt.Errorf("perPage debe estar entre 1 y %d", maxAPIPerPage)
+		}
+	}
+	return page, perPage, nil
+}
+
+func newPagination(page, perPage, total int) *apiPagination {
+	return &apiPagination{
+		Page:       page,
+		PerPage:    perPage,
+		Total:      total,
+		TotalPages: (total + perPage - 1) / perPage,
+	}
+}
+
+// paginate devuelve la porción de items correspondiente a la página
+func paginate[T any](items []T, page, perPage int) []T {
+	start := (page - 1) * perPage
+	if start >= len(items) {
+		return []T{}
+	}
+	end := start + perPage
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[start:end]
+}
+
+func (s *StatusPageService) apiStatus(w http.ResponseWriter, r *http.Request) {
+	if !allowMethods(w, r, http.MethodGet) {
+		return
+	}
+	overview, err := s.GetAllStatus()
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, apiResponse{Data: overview})
+}
+
+func (s *StatusPageService) apiStats(w http.ResponseWriter, r *http.Request) {
+	if !allowMethods(w, r, http.MethodGet) {
+		return
+	}
+	stats, err := s.GetStats()
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, apiResponse{Data: stats})
+}
+
+func (s *StatusPageService) apiConfig(w http.ResponseWriter, r *http.Request) {
+	if !allowMethods(w, r, http.MethodGet, http.MethodPut, http.MethodPatch) {
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		var req apiConfigRequest
+		if !decodeJSON(w, r, &req) {
+			return
+		}
+
+		current := s.currentConfig()
+		checkInterval, retentionDays := current.CheckInterval, current.RetentionDays
+		if req.CheckInterval != nil {
+			checkInterval = *req.CheckInterval
+		}
+		if req.RetentionDays != nil {
+			retentionDays = *req.RetentionDays
+		}
+		if checkInterval <= 0 {
+			writeAPIError(w, http.StatusBadRequest, "checkInterval debe ser mayor que 0")
+			return
+		}
+		if retentionDays < 0 {
+			writeAPIError(w, http.StatusBadRequest, "retentionDays no puede ser negativo")
+			return
+		}
+
+		if err := s.UpdateConfig(checkInterval, retentionDays); err != nil {
+			writeAPIError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
+	config := s.currentConfig()
+	writeJSON(w, http.StatusOK, apiResponse{Data: apiConfig{
+		CheckInterval: config.CheckInterval,
+		RetentionDays: config.RetentionDays,
+		Sites:         len(config.Sites),
+	}})
+}
+
+func (s *StatusPageService) apiSites(w http.ResponseWriter, r *http.Request) {
+	if !allowMethods(w, r, http.MethodGet, http.MethodPost) {
+		return
+	}
+
+	if r.Method == http.MethodPost {
+		s.apiAddSite(w, r)
+		return
+	}
+
+	page, perPage, err := parsePagination(r)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	sites, err := s.GetAllSites(r.URL.Query().Get("tag"))
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, apiResponse{
+		Data:       paginate(sites, page, perPage),
+		Pagination: newPagination(page, perPage, len(sites)),
+	})
+}
+
+func (s *StatusPageService) apiAddSite(w http.ResponseWriter, r *http.Request) {
+	var req apiSiteRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if err := validateSiteRequest(&req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := s.AddSite(req.Name, req.URL, req.Method, req.Timeout); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, errAlreadyExists) {
+			status = http.StatusConflict
+		}
+		writeAPIError(w, status, err.Error())
+		return
+	}
+
+	detail, _ := s.siteDetail(req.Name)
+	w.Header().Set("Location", "/api/v1/sites/"+url.PathEscape(req.Name))
+	writeJSON(w, http.StatusCreated, apiResponse{Data: detail})
+}
+
+// validateSiteRequest valida los campos de un sitio y completa método y timeout por defecto
+func validateSiteRequest(req *apiSiteRequest) error {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return fmt.Errorf("el nombre es obligatorio")
+	}
+	if u, err := url.Parse(req.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("la URL debe ser http:// o https://")
+	}
+	req.Method = strings.ToUpper(req.Method)
+	switch req.Method {
+	case "":
+		req.Method = http.MethodGet
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodOptions:
+	default:
+		return fmt.Errorf("método HTTP no soportado '%s'", req.Method)
+	}
+	if req.Timeout == 0 {
+		req.Timeout = 10
+	}
+	if req.Timeout < 1 || req.Timeout > 300 {
+		return fmt.Errorf("timeout debe estar entre 1 y 300 segundos")
+	}
+	return nil
+}
+
+// siteDetail devuelve el detalle de un sitio configurado
+func (s *StatusPageService) siteDetail(name string) (*SiteDetail, error) {
+	sites, err := s.GetAllSites("")
+	if err != nil {
+		return nil, err
+	}
+	for i := range sites {
+		if sites[i].Name == name {
+			return &sites[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *StatusPageService) apiSite(w http.ResponseWriter, r *http.Request) {
+	if !allowMethods(w, r, http.MethodGet, http.MethodDelete) {
+		return
+	}
+
+	name := r.PathValue("name")
+	if _, exists := s.findSite(name); !exists {
+		writeAPIError(w, http.StatusNotFound, fmt.Sprintf("sitio '%s' no encontrado", name))
+		return
+	}
+
+	if r.Method == http.MethodDelete {
+		if err := s.RemoveSite(name); err != nil {
+			writeAPIError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	detail, err := s.siteDetail(name)
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, apiResponse{Data: detail})
+}
+
+func (s *StatusPageService) apiSiteChecks(w http.ResponseWriter, r *http.Request) {
+	if !allowMethods(w, r, http.MethodGet) {
+		return
+	}
+
+	name := r.PathValue("name")
+	if _, exists := s.findSite(name); !exists {
+		writeAPIError(w, http.StatusNotFound, fmt.Sprintf("sitio '%s' no encontrado", name))
+		return
+	}
+
+	page, perPage, err := parsePagination(r)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	checks, total, err := s.siteChecksPage(name, perPage, (page-1)*perPage)
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, apiResponse{
+		Data:       checks,
+		Pagination: newPagination(page, perPage, total),
+	})
+}
+
+// siteChecksPage devuelve una página del historial de checks del sitio y el total
+func (s *StatusPageService) siteChecksPage(siteName string, limit, offset int) ([]StatusCheck, int, error) {
+	var total int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM status_checks WHERE site_name = ?`, siteName).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := s.db.Query(`
+	SELECT id, site_name, site_url, status, status_code, response_time, checked_at,
+		   COALESCE(error_message, ''), cert_expires_at
+	FROM status_checks
+	WHERE site_name = ?
+	ORDER BY checked_at DESC, id DESC
+	LIMIT ? OFFSET ?`, siteName, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	checks := []StatusCheck{}
+	for rows.Next() {
+		var check StatusCheck
+		var certExpiresAt sql.NullTime
+		err := rows.Scan(&check.ID, &check.SiteName, &check.SiteURL, &check.Status,
+			&check.StatusCode, &check.ResponseTime, &check.CheckedAt, &check.ErrorMessage, &certExpiresAt)
+		if err != nil {
+			return nil, 0, err
+		}
+		check.CertExpiresAt = nullTimePtr(certExpiresAt)
+		checks = append(checks, check)
+	}
+
+	return checks, total, rows.Err()
+}
+
+func (s *StatusPageService) apiMaintenances(w http.ResponseWriter, r *http.Request) {
+	if !allowMethods(w, r, http.MethodGet, http.MethodPost) {
+		return
+	}
+
+	if r.Method == http.MethodPost {
+		var req Maintenance
+		if !decodeJSON(w, r, &req) {
+			return
+		}
+		m, err := s.ScheduleMaintenance(req)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		w.Header().Set("Location", fmt.Sprintf("/api/v1/maintenance/%d", m.ID))
+		writeJSON(w, http.StatusCreated, apiResponse{Data: m})
+		return
+	}
+
+	maintenances, err := s.GetMaintenances(r.URL.Query().Get("past") == "true")
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if maintenances == nil {
+		maintenances = []Maintenance{}
+	}
+	writeJSON(w, http.StatusOK, apiResponse{Data: maintenances})
+}
+
+func (s *StatusPageService) apiMaintenance(w http.ResponseWriter, r *http.Request) {
+	if !allowMethods(w, r, http.MethodDelete) {
+		return
+	}
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, "identificador de mantenimiento inválido")
+		return
+	}
+	if err := s.CancelMaintenance(id); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, errNotFound) {
+			status = http.StatusNotFound
+		}
+		writeAPIError(w, status, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type apiManualIncidentRequest struct {
+	Title   string   `json:"title"`
+	Status  string   `json:"status"`
+	Message string   `json:"message"`
+	Sites   []string `json:"sites"`
+	Groups  []string `json:"groups"`
+}
+
+func (s *StatusPageService) apiManualIncidents(w http.ResponseWriter, r *http.Request) {
+	if !allowMethods(w, r, http.MethodGet, http.MethodPost) {
+		return
+	}
+
+	if r.Method == http.MethodPost {
+		var req apiManualIncidentRequest
+		if !decodeJSON(w, r, &req) {
+			return
+		}
+		incident, err := s.CreateManualIncident(req.Title, req.Status, req.Message, req.Sites, req.Groups)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		w.Header().Set("Location", fmt.Sprintf("/api/v1/manual-incidents/%d", incident.ID))
+		writeJSON(w, http.StatusCreated, apiResponse{Data: incident})
+		return
+	}
+
+	incidents, err := s.GetManualIncidents(0)
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if incidents == nil {
+		incidents = []ManualIncident{}
+	}
+	writeJSON(w, http.StatusOK, apiResponse{Data: incidents})
+}
+
+func (s *StatusPageService) apiManualIncidentUpdates(w http.ResponseWriter, r *http.Request) {
+	if !allowMethods(w, r, http.MethodPost) {
+		return
+	}
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, "identificador de incidente inválido")
+		return
+	}
+	if _, err := s.GetManualIncident(id); err != nil {
+		writeAPIError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	var req apiManualIncidentRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	incident, err := s.AddIncidentUpdate(id, req.Status, req.Message)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, apiResponse{Data: incident})
+}
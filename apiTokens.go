@@ -0,0 +1,275 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Alcances de los tokens de la API; cada uno incluye a los anteriores
+const (
+	ScopeRead  = "read"
+	ScopeWrite = "write"
+	ScopeAdmin = "admin"
+)
+
+var scopeLevels = map[string]int{ScopeRead: 1, ScopeWrite: 2, ScopeAdmin: 3}
+
+// Prefijo de los tokens generados (facilita detectarlos en logs y repositorios)
+const apiTokenPrefix = "sp_"
+
+// Frecuencia máxima con la que se actualiza la fecha de último uso
+const tokenLastUsedInterval = time.Minute
+
+// Token de la API; el valor en claro solo se devuelve al crearlo
+type APIToken struct {
+	ID         int64      `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"` // primeros caracteres para identificarlo
+	Scope      string     `json:"scope"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+}
+
+// Token recién creado con su valor en claro
+type NewAPIToken struct {
+	APIToken
+	Token string `json:"token"`
+}
+
+func (s *StatusPageService) initAPITokensDB() error {
+	createTableSQL := `
+	CREATE TABLE IF NOT EXISTS api_tokens (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL,
+		token_hash TEXT NOT NULL UNIQUE,
+		prefix TEXT NOT NULL,
+		scope TEXT NOT NULL,
+		created_at DATETIME NOT NULL,
+		last_used_at DATETIME,
+		revoked_at DATETIME
+	);
+	`
+
+	_, err := s.db.Exec(createTableSQL)
+	return err
+}
+
+func hashAPIToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// CreateAPIToken genera un token con el alcance indicado ("read", "write" o "admin")
+func (s *StatusPageService) CreateAPIToken(name, scope string) (*NewAPIToken, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("el token debe tener un nombre")
+	}
+	if _, ok := scopeLevels[scope]; !ok {
+		return nil, fmt.Errorf("alcance desconocido '%s' (read, write o admin)", scope)
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	token := apiTokenPrefix + hex.EncodeToString(secret)
+	prefix := token[:len(apiTokenPrefix)+6]
+
+	now := time.Now().UTC()
+	result, err := s.db.Exec(`INSERT INTO api_tokens (name, token_hash, prefix, scope, created_at) VALUES (?, ?, ?, ?, ?)`,
+		name, hashAPIToken(token), prefix, scope, now)
+	if err != nil {
+		return nil, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	return &NewAPIToken{
+		APIToken: APIToken{ID: id, Name: name, Prefix: prefix, Scope: scope, CreatedAt: now},
+		Token:    token,
+	}, nil
+}
+
+// RevokeAPIToken revoca un token; las peticiones que lo usen serán rechazadas
+func (s *StatusPageService) RevokeAPIToken(id int64) error {
+	result, err := s.db.Exec(`UPDATE api_tokens SET revoked_at = ? WHERE id = ? AND revoked_at IS NULL`, time.Now().UTC(), id)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("token %d no encontrado o ya revocado", id)
+	}
+	return nil
+}
+
+const apiTokenColumns = `id, name, prefix, scope, created_at, last_used_at, revoked_at`
+
+func scanAPIToken(row interface{ Scan(...any) error }) (APIToken, error) {
+	var token APIToken
+	var lastUsedAt, revokedAt sql.NullTime
+	err := row.Scan(&token.ID, &token.Name, &token.Prefix, &token.Scope, &token.CreatedAt, &lastUsedAt, &revokedAt)
+	token.LastUsedAt = nullTimePtr(lastUsedAt)
+	token.RevokedAt = nullTimePtr(revokedAt)
+	return token, err
+}
+
+// GetAPITokens devuelve los tokens creados (sin su valor)
+func (s *StatusPageService) GetAPITokens() ([]APIToken, error) {
+	rows, err := s.db.Query(`SELECT ` + apiTokenColumns + ` FROM api_tokens ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tokens := []APIToken{}
+	for rows.Next() {
+		token, err := scanAPIToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, rows.Err()
+}
+
+// authenticateToken devuelve el token activo correspondiente al valor en claro
+func (s *StatusPageService) authenticateToken(value string) (*APIToken, error) {
+	row := s.db.QueryRow(`SELECT `+apiTokenColumns+` FROM api_tokens WHERE token_hash = ? AND revoked_at IS NULL`,
+		hashAPIToken(value))
+	token, err := scanAPIToken(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= tokenLastUsedInterval {
+		if _, err := s.db.Exec(`UPDATE api_tokens SET last_used_at = ? WHERE id = ?`, now, token.ID); err != nil {
+			log.Printf("Error actualizando último uso del token %d: %v", token.ID, err)
+		}
+	}
+	return &token, nil
+}
+
+// requestToken extrae el token de "Authorization: Bearer" o "X-API-Key"; con
+// allowQuery también del parámetro access_token, para EventSource que no permite
+// cabeceras. En el resto de rutas no se acepta porque la URL acaba en logs e historial
+func requestToken(r *http.Request, allowQuery bool) string {
+	if auth := r.Header.Get("Authorization"); auth != "" {
+		if scheme, value, ok := strings.Cut(auth, " "); ok && strings.EqualFold(scheme, "Bearer") {
+			return strings.TrimSpace(value)
+		}
+	}
+	if key := r.Header.Get("X-API-Key"); key != "" {
+		return key
+	}
+	if allowQuery {
+		return r.URL.Query().Get("access_token")
+	}
+	return ""
+}
+
+// authorize exige un token con readScope para GET/HEAD y writeScope para el resto de métodos
+func (s *StatusPageService) authorize(readScope, writeScope string, next http.HandlerFunc) http.HandlerFunc {
+	return s.authorizeRequest(readScope, writeScope, false, next)
+}
+
+// authorizeEvents es authorize para /api/v1/events, que acepta además access_token
+func (s *StatusPageService) authorizeEvents(next http.HandlerFunc) http.HandlerFunc {
+	return s.authorizeRequest(ScopeRead, ScopeRead, true, next)
+}
+
+func (s *StatusPageService) authorizeRequest(readScope, writeScope string, allowQuery bool, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		required := writeScope
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			required = readScope
+		}
+
+		value := requestToken(r, allowQuery)
+		if value == "" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="status-page"`)
+			writeAPIError(w, http.StatusUnauthorized, "se requiere un token de API")
+			return
+		}
+		token, err := s.authenticateToken(value)
+		if err != nil {
+			writeAPIError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if token == nil {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="status-page", error="invalid_token"`)
+			writeAPIError(w, http.StatusUnauthorized, "token de API inválido o revocado")
+			return
+		}
+		if scopeLevels[token.Scope] < scopeLevels[required] {
+			writeAPIError(w, http.StatusForbidden, fmt.Sprintf("el token no tiene el alcance '%s'", required))
+			return
+		}
+		next(w, r)
+	}
+}
+
+type apiTokenRequest struct {
+	Name  string `json:"name"`
+	Scope string `json:"scope"`
+}
+
+func (s *StatusPageService) apiTokens(w http.ResponseWriter, r *http.Request) {
+	if !allowMethods(w, r, http.MethodGet, http.MethodPost) {
+		return
+	}
+
+	if r.Method == http.MethodPost {
+		var req apiTokenRequest
+		if !decodeJSON(w, r, &req) {
+			return
+		}
+		token, err := s.CreateAPIToken(req.Name, req.Scope)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		w.Header().Set("Location", fmt.Sprintf("/api/v1/tokens/%d", token.ID))
+		writeJSON(w, http.StatusCreated, apiResponse{Data: token})
+		return
+	}
+
+	tokens, err := s.GetAPITokens()
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, apiResponse{Data: tokens})
+}
+
+func (s *StatusPageService) apiToken(w http.ResponseWriter, r *http.Request) {
+	if !allowMethods(w, r, http.MethodDelete) {
+		return
+	}
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, "identificador de token inválido")
+		return
+	}
+	if err := s.RevokeAPIToken(id); err != nil {
+		writeAPIError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAuthorize(t *testing.T) {
+	s := newTestService(t)
+	s.config.Sites = []Site{{Name: "api", URL: "https://api.example.com", Method: "GET", Timeout: 5}}
+	s.config.StatusPage.PublicSites = []string{"*"}
+	handler := s.httpHandler()
+
+	tokens := make(map[string]string)
+	for _, scope := range []string{ScopeRead, ScopeWrite, ScopeAdmin} {
+		created, err := s.CreateAPIToken(scope, scope)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tokens[scope] = created.Token
+	}
+	revoked, err := s.CreateAPIToken("revocado", ScopeAdmin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.RevokeAPIToken(revoked.ID); err != nil {
+		t.Fatal(err)
+	}
+	tokens["revocado"] = revoked.Token
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		header string // "Authorization" o "X-API-Key"
+		token  string
+		code   int
+	}{
+		{"sin token", http.MethodGet, "/api/v1/status", "", "", http.StatusUnauthorized},
+		{"token desconocido", http.MethodGet, "/api/v1/status", "Authorization", "sp_desconocido", http.StatusUnauthorized},
+		{"token revocado", http.MethodGet, "/api/v1/status", "Authorization", tokens["revocado"], http.StatusUnauthorized},
+		{"lectura", http.MethodGet, "/api/v1/status", "Authorization", tokens[ScopeRead], http.StatusOK},
+		{"lectura con X-API-Key", http.MethodGet, "/api/v1/sites", "X-API-Key", tokens[ScopeRead], http.StatusOK},
+		{"read no puede escribir", http.MethodDelete, "/api/v1/maintenance/1", "Authorization", tokens[ScopeRead], http.StatusForbidden},
+		{"write puede escribir", http.MethodDelete, "/api/v1/maintenance/1", "Authorization", tokens[ScopeWrite], http.StatusNotFound},
+		{"write no administra tokens", http.MethodGet, "/api/v1/tokens", "Authorization", tokens[ScopeWrite], http.StatusForbidden},
+		{"write no cambia la configuración", http.MethodPatch, "/api/v1/config", "Authorization", tokens[ScopeWrite], http.StatusForbidden},
+		{"admin administra tokens", http.MethodGet, "/api/v1/tokens", "Authorization", tokens[ScopeAdmin], http.StatusOK},
+		{"métricas sin token", http.MethodGet, "/metrics", "", "", http.StatusUnauthorized},
+		{"access_token fuera de events", http.MethodGet, "/api/v1/status?access_token=" + tokens[ScopeAdmin], "", "", http.StatusUnauthorized},
+		{"página de estado anónima", http.MethodGet, "/status", "", "", http.StatusOK},
+		{"raíz anónima", http.MethodGet, "/", "", "", http.StatusOK},
+		{"badge anónimo", http.MethodGet, "/badge/api/status.svg", "", "", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			switch tt.header {
+			case "Authorization":
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			case "X-API-Key":
+				req.Header.Set("X-API-Key", tt.token)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.code {
+				t.Errorf("código = %d, se esperaba %d: %s", rec.Code, tt.code, rec.Body)
+			}
+			if rec.Code == http.StatusUnauthorized && rec.Header().Get("WWW-Authenticate") == "" {
+				t.Error("falta la cabecera WWW-Authenticate")
+			}
+		})
+	}
+
+	// EventSource no puede enviar cabeceras: /api/v1/events acepta access_token
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for token, code := range map[string]int{tokens[ScopeRead]: http.StatusOK, tokens["revocado"]: http.StatusUnauthorized} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/events?access_token="+token, nil).WithContext(ctx)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != code {
+			t.Errorf("events con access_token: %d, se esperaba %d", rec.Code, code)
+		}
+	}
+}
+
+// last_used_at se actualiza como mucho una vez por tokenLastUsedInterval
+func TestAuthenticateTokenLastUsed(t *testing.T) {
+	s := newTestService(t)
+	created, err := s.CreateAPIToken("ci", ScopeRead)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lastUsed := func() time.Time {
+		t.Helper()
+		tokens, err := s.GetAPITokens()
+		if err != nil || len(tokens) != 1 || tokens[0].LastUsedAt == nil {
+			t.Fatalf("tokens = %+v, %v", tokens, err)
+		}
+		return *tokens[0].LastUsedAt
+	}
+	setLastUsed := func(at time.Time) {
+		t.Helper()
+		if _, err := s.db.Exec(`UPDATE api_tokens SET last_used_at = ?`, at); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if token, err := s.authenticateToken(created.Token); err != nil || token == nil {
+		t.Fatalf("token = %+v, %v", token, err)
+	}
+	if first := lastUsed(); time.Since(first) > time.Minute {
+		t.Errorf("primer uso = %v", first)
+	}
+
+	recent := time.Now().UTC().Add(-30 * time.Second).Truncate(time.Second)
+	setLastUsed(recent)
+	s.authenticateToken(created.Token)
+	if got := lastUsed(); !got.Equal(recent) {
+		t.Errorf("dentro del intervalo no debe actualizarse: %v, se esperaba %v", got, recent)
+	}
+
+	old := time.Now().UTC().Add(-2 * tokenLastUsedInterval)
+	setLastUsed(old)
+	s.authenticateToken(created.Token)
+	if got := lastUsed(); !got.After(old.Add(tokenLastUsedInterval)) {
+		t.Errorf("fuera del intervalo debe actualizarse: %v", got)
+	}
+}
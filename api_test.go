@@ -0,0 +1,68 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Altas simultáneas del mismo sitio: solo una se guarda y el resto recibe 409
+func TestAPIAddSiteConflict(t *testing.T) {
+	s := newTestService(t)
+
+	codes := make([]int, 8)
+	var wg sync.WaitGroup
+	for i := range codes {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			body := strings.NewReader(`{"name": "api", "url": "https://api.example.com"}`)
+			rec := httptest.NewRecorder()
+			s.apiAddSite(rec, httptest.NewRequest(http.MethodPost, "/api/v1/sites", body))
+			codes[i] = rec.Code
+		}(i)
+	}
+	wg.Wait()
+
+	created := 0
+	for _, code := range codes {
+		switch code {
+		case http.StatusCreated:
+			created++
+		case http.StatusConflict:
+		default:
+			t.Errorf("código inesperado %d", code)
+		}
+	}
+	if sites := s.currentConfig().Sites; created != 1 || len(sites) != 1 {
+		t.Errorf("creados = %d, sitios = %+v", created, sites)
+	}
+}
+
+func TestAPIMaintenanceDelete(t *testing.T) {
+	s := newTestService(t)
+	remove := func(id string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/maintenance/"+id, nil)
+		req.SetPathValue("id", id)
+		rec := httptest.NewRecorder()
+		s.apiMaintenance(rec, req)
+		return rec
+	}
+
+	if rec := remove("7"); rec.Code != http.StatusNotFound {
+		t.Errorf("mantenimiento inexistente: %d %s", rec.Code, rec.Body)
+	}
+	if rec := remove("x"); rec.Code != http.StatusBadRequest {
+		t.Errorf("identificador inválido: %d %s", rec.Code, rec.Body)
+	}
+
+	// Un error de la base de datos no se presenta como "no encontrado"
+	if _, err := s.db.Exec(`DROP TABLE maintenance_windows`); err != nil {
+		t.Fatal(err)
+	}
+	if rec := remove("7"); rec.Code != http.StatusInternalServerError {
+		t.Errorf("error de la base de datos: %d %s", rec.Code, rec.Body)
+	}
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+// Colores de los badges (paleta de shields.io)
+const (
+	badgeGreen       = "#4c1"
+	badgeYellowGreen = "#97ca00"
+	badgeYellow      = "#dfb317"
+	badgeOrange      = "#fe7d37"
+	badgeRed         = "#e05d44"
+	badgeBlue        = "#007ec6"
+	badgeGrey        = "#9f9f9f"
+)
+
+// Ventanas admitidas por los badges de uptime y tiempo de respuesta
+var badgeWindows = map[string]struct {
+	modifier string // modificador de datetime() de SQLite
+	days     int    // días para las estadísticas diarias (0 = usar los checks)
+}{
+	"24h": {modifier: "-24 hours"},
+	"7d":  {modifier: "-7 days", days: 7},
+	"30d": {modifier: "-30 days", days: 30},
+}
+
+type badge struct {
+	Label      string
+	Message    string
+	Color      string
+	LabelWidth int
+	Width      int
+}
+
+// textWidth aproxima el ancho en píxeles del texto en Verdana 11px
+func textWidth(text string) int {
+	return utf8.RuneCountInString(text)*7 + 10
+}
+
+func newBadge(label, message, color string) badge {
+	b := badge{Label: label, Message: message, Color: color, LabelWidth: textWidth(label)}
+	b.Width = b.LabelWidth + textWidth(message)
+	return b
+}
+
+var badgeTemplate = template.Must(template.New("badge").Parse(`<svg xmlns="http://www.w3.org/2000/svg" width="{{.Width}}" height="20" role="img" aria-label="{{.Label}}: {{.Message}}">
+<title>{{.Label}}: {{.Message}}</title>
+<linearGradient id="s" x2="0" y2="100%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>
+<clipPath id="r"><rect width="{{.Width}}" height="20" rx="3" fill="#fff"/></clipPath>
+<g clip-path="url(#r)">
+<rect width="{{.LabelWidth}}" height="20" fill="#555"/>
+<rect x="{{.LabelWidth}}" width="{{.MessageWidth}}" height="20" fill="{{.Color}}"/>
+<rect width="{{.Width}}" height="20" fill="url(#s)"/>
+</g>
+<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="110" text-rendering="geometricPrecision">
+<text x="{{.LabelCenter}}" y="150" fill="#010101" fill-opacity=".3" transform="scale(.1)">{{.Label}}</text>
+<text x="{{.LabelCenter}}" y="140" transform="scale(.1)">{{.Label}}</text>
+<text x="{{.MessageCenter}}" y="150" fill="#010101" fill-opacity=".3" transform="scale(.1)">{{.Message}}</text>
+<text x="{{.MessageCenter}}" y="140" transform="scale(.1)">{{.Message}}</text>
+</g>
+</svg>
+`))
+
+// MessageWidth es el ancho de la parte derecha del badge
+func (b badge) MessageWidth() int {
+	return b.Width - b.LabelWidth
+}
+
+// LabelCenter es el centro de la etiqueta en la escala 10x del texto
+func (b badge) LabelCenter() int {
+	return b.LabelWidth * 5
+}
+
+// MessageCenter es el centro del mensaje en la escala 10x del texto
+func (b badge) MessageCenter() int {
+	return b.LabelWidth*10 + b.MessageWidth()*5
+}
+
+func uptimeColor(percent float64) string {
+	switch {
+	case percent >= 99.9:
+		return badgeGreen
+	case percent >= 99:
+		return badgeYellowGreen
+	case percent >= 95:
+		return badgeYellow
+	case percent >= 90:
+		return badgeOrange
+	}
+	return badgeRed
+}
+
+func responseTimeColor(ms float64) string {
+	switch {
+	case ms < 300:
+		return badgeGreen
+	case ms < 1000:
+		return badgeYellowGreen
+	case ms < 3000:
+		return badgeYellow
+	}
+	return badgeRed
+}
+
+// statusBadge describe el estado actual del sitio
+func (s *StatusPageService) statusBadge(site Site) (badge, error) {
+	detail, err := s.siteDetail(site.Name)
+	if err != nil {
+		return badge{}, err
+	}
+
+	maintenances, err := s.GetMaintenances(false)
+	if err != nil {
+		return badge{}, err
+	}
+	for _, m := range maintenances {
+		if m.Status() == "in_progress" && m.Affects(site) {
+			return newBadge("estado", "mantenimiento", badgeBlue), nil
+		}
+	}
+
+	switch detail.Status {
+	case "up":
+		return newBadge("estado", "operativo", badgeGreen), nil
+	case "down":
+		return newBadge("estado", "caído", badgeRed), nil
+	case "unreachable":
+		return newBadge("estado", "sin conexión", badgeGrey), nil
+	}
+	return newBadge("estado", "sin datos", badgeGrey), nil
+}
+
+// uptimeBadge describe la disponibilidad del sitio en la ventana
+func (s *StatusPageService) uptimeBadge(site Site, window string) (badge, error) {
+	w := badgeWindows[window]
+	var total, up int
+	if w.days > 0 {
+		stats, err := s.siteDailyStats(site.Name, w.days)
+		if err != nil {
+			return badge{}, err
+		}
+		for _, stat := range stats {
+			total += stat.TotalChecks
+			up += stat.UpChecks
+		}
+	} else {
+		err := s.db.QueryRow(`
+		SELECT COUNT(*), COALESCE(SUM(CASE WHEN status = 'up' THEN 1 ELSE 0 END), 0)
+		FROM status_checks
+		WHERE site_name = ? AND status IN ('up', 'down') AND checked_at >= datetime('now', ?)`,
+			site.Name, w.modifier).Scan(&total, &up)
+		if err != nil {
+			return badge{}, err
+		}
+	}
+
+	label := "uptime " + window
+	if total == 0 {
+		return newBadge(label, "sin datos", badgeGrey), nil
+	}
+	percent := float64(up) / float64(total) * 100
+	message := fmt.Sprintf("%.2f%%", percent)
+	if percent == 100 {
+		message = "100%"
+	}
+	return newBadge(label, message, uptimeColor(percent)), nil
+}
+
+// responseTimeBadge describe el tiempo de respuesta medio de los checks correctos en la ventana
+func (s *StatusPageService) responseTimeBadge(site Site, window string) (badge, error) {
+	var avg *float64
+	err := s.db.QueryRow(`
+	SELECT AVG(response_time) FROM status_checks
+	WHERE site_name = ? AND status = 'up' AND checked_at >= datetime('now', ?)`,
+		site.Name, badgeWindows[window].modifier).Scan(&avg)
+	if err != nil {
+		return badge{}, err
+	}
+
+	label := "respuesta " + window
+	if avg == nil {
+		return newBadge(label, "sin datos", badgeGrey), nil
+	}
+	return newBadge(label, fmt.Sprintf("%.0f ms", *avg), responseTimeColor(*avg)), nil
+}
+
+// handleBadge sirve /badge/{site}/{kind}.svg con kind "status", "uptime" o "response".
+// ?window=24h|7d|30d elige la ventana y ?label= reemplaza la etiqueta.
+func (s *StatusPageService) handleBadge(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "método no permitido", http.StatusMethodNotAllowed)
+		return
+	}
+
+	site, ok := s.findSite(r.PathValue("site"))
+	if !ok || !s.currentConfig().StatusPage.isPublic(site) {
+		http.NotFound(w, r)
+		return
+	}
+
+	window := r.URL.Query().Get("window")
+	if window == "" {
+		window = "24h"
+	}
+	if _, ok := badgeWindows[window]; !ok {
+		http.Error(w, "ventana inválida (24h, 7d o 30d)", http.StatusBadRequest)
+		return
+	}
+
+	var b badge
+	var err error
+	maxAge := 300
+	switch r.PathValue("kind") {
+	case "status.svg":
+		b, err = s.statusBadge(site)
+		maxAge = 60
+	case "uptime.svg":
+		b, err = s.uptimeBadge(site, window)
+	case "response.svg":
+		b, err = s.responseTimeBadge(site, window)
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		log.Printf("Error generando badge de %s: %v", site.Name, err)
+		http.Error(w, "error generando el badge", http.StatusInternalServerError)
+		return
+	}
+	if label := strings.TrimSpace(r.URL.Query().Get("label")); label != "" {
+		b = newBadge(label, b.Message, b.Color)
+	}
+
+	var buf bytes.Buffer
+	if err := badgeTemplate.Execute(&buf, b); err != nil {
+		log.Printf("Error generando badge de %s: %v", site.Name, err)
+		http.Error(w, "error generando el badge", http.StatusInternalServerError)
+		return
+	}
+
+	sum := sha1.Sum(buf.Bytes())
+	etag := `"` + hex.EncodeToString(sum[:8]) + `"`
+	w.Header().Set("Content-Type", "image/svg+xml; charset=utf-8")
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", maxAge))
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Write(buf.Bytes())
+}
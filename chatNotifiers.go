@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Configuración de los canales de chat (Slack, Discord, Microsoft Teams)
+type ChatConfig struct {
+	WebhookURL string `json:"webhookUrl"`
+	// Enlace incluido en el mensaje; por defecto la URL del sitio
+	LinkURL string `json:"linkUrl,omitempty"`
+}
+
+// Colores de estado usados en los mensajes de chat
+const (
+	colorDown     = "#dc2626"
+	colorRecovery = "#16a34a"
+)
+
+type chatFact struct {
+	Name  string
+	Value string
+}
+
+func eventColor(event NotificationEvent) string {
+	if event.IsResolved() {
+		return colorRecovery
+	}
+	return colorDown
+}
+
+// eventFacts devuelve los datos del check que se muestran en los mensajes
+func eventFacts(event NotificationEvent) []chatFact {
+	facts := []chatFact{
+		{"Código HTTP", strconv.Itoa(event.Check.StatusCode)},
+		{"Tiempo de respuesta", fmt.Sprintf("%d ms", event.Check.ResponseTime)},
+	}
+	if event.Alert != nil {
+		facts = append(facts, chatFact{"Alerta", event.Alert.Message})
+	}
+	if event.Type == EventRecovery || event.Type == EventReminder || event.Type == EventEscalation {
+		facts = append(facts, chatFact{"Duración de la caída", event.Duration().String()})
+	}
+	if event.Check.ErrorMessage != "" {
+		facts = append(facts, chatFact{"Error", event.Check.ErrorMessage})
+	}
+	if event.Site.Group != "" {
+		facts = append(facts, chatFact{"Grupo", event.Site.Group})
+	}
+	return facts
+}
+
+func (c ChatConfig) link(event NotificationEvent) string {
+	if c.LinkURL != "" {
+		return c.LinkURL
+	}
+	return event.Site.URL
+}
+
+// postChatPayload serializa el payload y lo envía al webhook del chat
+func postChatPayload(ctx context.Context, url string, payload any) (string, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", &notificationRenderError{err}
+	}
+	headers := map[string]string{"Content-Type": "application/json"}
+	return string(body), postNotification(ctx, http.MethodPost, url, headers, body)
+}
+
+type SlackNotifier struct {
+	config    ChatConfig
+	templates *messageTemplates
+}
+
+func (n *SlackNotifier) Send(ctx context.Context, event NotificationEvent) (string, error) {
+	rendered, err := n.templates.render(event)
+	if err != nil {
+		return "", err
+	}
+
+	blocks := []map[string]any{
+		{
+			"type": "section",
+			"text": map[string]any{
+				"type": "mrkdwn",
+				"text": fmt.Sprintf("*%s*\n<%s|%s>", rendered.Title, event.Site.URL, event.Site.URL),
+			},
+		},
+		{
+			"type": "section",
+			"text": map[string]any{"type": "mrkdwn", "text": rendered.Message},
+		},
+		{
+			"type": "actions",
+			"elements": []map[string]any{{
+				"type": "button",
+				"text": map[string]any{"type": "plain_text", "text": "Abrir"},
+				"url":  n.config.link(event),
+			}},
+		},
+	}
+
+	payload := map[string]any{
+		"text": rendered.Title,
+		"attachments": []map[string]any{{
+			"color":  eventColor(event),
+			"blocks": blocks,
+		}},
+	}
+
+	return postChatPayload(ctx, n.config.WebhookURL, payload)
+}
+
+type DiscordNotifier struct {
+	config    ChatConfig
+	templates *messageTemplates
+}
+
+func (n *DiscordNotifier) Send(ctx context.Context, event NotificationEvent) (string, error) {
+	rendered, err := n.templates.render(event)
+	if err != nil {
+		return "", err
+	}
+	color, _ := strconv.ParseInt(eventColor(event)[1:], 16, 64)
+
+	payload := map[string]any{
+		"username": "StatusPage Monitor",
+		"embeds": []map[string]any{{
+			"title":       rendered.Title,
+			"description": rendered.Message,
+			"url":         n.config.link(event),
+			"color":       color,
+			"timestamp":   event.OccurredAt.UTC().Format(time.RFC3339),
+		}},
+	}
+
+	return postChatPayload(ctx, n.config.WebhookURL, payload)
+}
+
+type TeamsNotifier struct {
+	config    ChatConfig
+	templates *messageTemplates
+}
+
+func (n *TeamsNotifier) Send(ctx context.Context, event NotificationEvent) (string, error) {
+	rendered, err := n.templates.render(event)
+	if err != nil {
+		return "", err
+	}
+
+	color := "Attention"
+	if event.IsResolved() {
+		color = "Good"
+	}
+
+	card := map[string]any{
+		"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+		"type":    "AdaptiveCard",
+		"version": "1.4",
+		"body": []map[string]any{
+			{
+				"type":   "TextBlock",
+				"text":   rendered.Title,
+				"size":   "Large",
+				"weight": "Bolder",
+				"color":  color,
+				"wrap":   true,
+			},
+			{
+				"type": "TextBlock",
+				"text": event.Site.URL,
+				"wrap": true,
+			},
+			{
+				"type": "TextBlock",
+				// Teams necesita una línea en blanco entre párrafos
+				"text": strings.ReplaceAll(rendered.Message, "\n", "\n\n"),
+				"wrap": true,
+			},
+		},
+		"actions": []map[string]any{{
+			"type":  "Action.OpenUrl",
+			"title": "Abrir",
+			"url":   n.config.link(event),
+		}},
+	}
+
+	payload := map[string]any{
+		"type": "message",
+		"attachments": []map[string]any{{
+			"contentType": "application/vnd.microsoft.card.adaptive",
+			"content":     card,
+		}},
+	}
+
+	return postChatPayload(ctx, n.config.WebhookURL, payload)
+}
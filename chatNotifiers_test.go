@@ -0,0 +1,70 @@
+package main
+
+import "testing"
+
+func TestChatNotifierPayloads(t *testing.T) {
+	tests := []struct {
+		channelType string
+		eventType   string
+		want        map[string]any // ruta -> valor esperado
+	}{
+		{"slack", EventDown, map[string]any{
+			"text":                                  "🔴 api está caído",
+			"attachments.0.color":                   colorDown,
+			"attachments.0.blocks.0.text.type":      "mrkdwn",
+			"attachments.0.blocks.0.text.text":      "*🔴 api está caído*\n<https://api.example.com|https://api.example.com>",
+			"attachments.0.blocks.2.elements.0.url": "https://status.example.com",
+		}},
+		{"slack", EventRecovery, map[string]any{
+			"text":                "✅ api se ha recuperado",
+			"attachments.0.color": colorRecovery,
+		}},
+		{"discord", EventDown, map[string]any{
+			"username":           "StatusPage Monitor",
+			"embeds.0.title":     "🔴 api está caído",
+			"embeds.0.url":       "https://status.example.com",
+			"embeds.0.color":     float64(0xdc2626),
+			"embeds.0.timestamp": "2024-05-01T10:05:00Z",
+		}},
+		{"discord", EventRecovery, map[string]any{
+			"embeds.0.title": "✅ api se ha recuperado",
+			"embeds.0.color": float64(0x16a34a),
+		}},
+		{"teams", EventDown, map[string]any{
+			"type":                                 "message",
+			"attachments.0.contentType":            "application/vnd.microsoft.card.adaptive",
+			"attachments.0.content.type":           "AdaptiveCard",
+			"attachments.0.content.body.0.text":    "🔴 api está caído",
+			"attachments.0.content.body.0.color":   "Attention",
+			"attachments.0.content.body.1.text":    "https://api.example.com",
+			"attachments.0.content.actions.0.type": "Action.OpenUrl",
+			"attachments.0.content.actions.0.url":  "https://status.example.com",
+		}},
+		{"teams", EventRecovery, map[string]any{
+			"attachments.0.content.body.0.text":  "✅ api se ha recuperado",
+			"attachments.0.content.body.0.color": "Good",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.channelType+"/"+tt.eventType, func(t *testing.T) {
+			ts := newCaptureServer(t)
+			chat := &ChatConfig{WebhookURL: ts.URL, LinkURL: "https://status.example.com"}
+			channel := NotificationChannel{Name: tt.channelType, Type: tt.channelType}
+			switch tt.channelType {
+			case "slack":
+				channel.Slack = chat
+			case "discord":
+				channel.Discord = chat
+			case "teams":
+				channel.Teams = chat
+			}
+
+			payload, captured := sendCaptured(t, ts, channel, testEvent(tt.eventType))
+			if ct := captured.header.Get("Content-Type"); ct != "application/json" {
+				t.Errorf("Content-Type = %q", ct)
+			}
+			checkJSONPaths(t, payload, tt.want)
+		})
+	}
+}
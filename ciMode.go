@@ -0,0 +1,267 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// checkOnce verifica los sitios una sola vez, en paralelo por niveles de
+// dependencia. Con save los resultados se guardan en status.db (con sus
+// notificaciones); sin save no se accede a la base de datos y las dependencias
+// se resuelven con los resultados de esta misma ejecución.
+func (s *StatusPageService) checkOnce(sites []Site, save bool) []StatusCheck {
+	var mu sync.Mutex
+	results := make(map[string]StatusCheck, len(sites))
+
+	for _, level := range dependencyLevels(sites) {
+		var wg sync.WaitGroup
+		for _, site := range level {
+			wg.Add(1)
+			go func(site Site) {
+				defer wg.Done()
+
+				var check StatusCheck
+				if save {
+					check = s.checkSite(site)
+				} else {
+					check = s.performCheck(site)
+					check.CheckedAt = time.Now().UTC()
+					s.metrics.observeCheck(check)
+				}
+
+				mu.Lock()
+				defer mu.Unlock()
+				if !save && check.Status == "down" {
+					for _, dep := range site.DependsOn {
+						if parent, ok := results[dep]; ok && isFailingStatus(parent.Status) {
+							markUnreachable(&check, dep)
+							break
+						}
+					}
+				}
+				results[site.Name] = check
+			}(site)
+		}
+		wg.Wait()
+	}
+
+	checks := make([]StatusCheck, 0, len(sites))
+	for _, site := range sites {
+		checks = append(checks, results[site.Name])
+	}
+	return checks
+}
+
+// Resultado de "ci" en formato JSON
+type ciReport struct {
+	StartedAt time.Time  `json:"startedAt"`
+	Duration  float64    `json:"durationSeconds"`
+	Saved     bool       `json:"saved"`
+	Total     int        `json:"total"`
+	Failed    int        `json:"failed"`
+	Results   []ciResult `json:"results"`
+}
+
+type ciResult struct {
+	Group string `json:"group,omitempty"`
+	StatusCheck
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+func junitSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+// writeJUnitReport genera un testsuite por grupo; las caídas son fallos y los
+// sitios no alcanzables por una dependencia caída se marcan como omitidos
+func writeJUnitReport(w io.Writer, report ciReport) error {
+	suites := junitTestSuites{
+		Name: "status-page",
+		Time: junitSeconds(time.Duration(report.Duration * float64(time.Second))),
+	}
+	index := make(map[string]int)
+	for _, result := range report.Results {
+		group := result.Group
+		if group == "" {
+			group = "Sin grupo"
+		}
+		i, ok := index[group]
+		if !ok {
+			i = len(suites.Suites)
+			index[group] = i
+			suites.Suites = append(suites.Suites, junitTestSuite{
+				Name:      group,
+				Timestamp: report.StartedAt.Format("2006-01-02T15:04:05"),
+			})
+		}
+		suite := &suites.Suites[i]
+
+		testCase := junitTestCase{
+			Name:      result.SiteName,
+			Classname: "status-page." + group,
+			Time:      junitSeconds(time.Duration(result.ResponseTime) * time.Millisecond),
+		}
+		message := fmt.Sprintf("%s %s: %s (código %d)", result.SiteName, result.SiteURL, result.Status, result.StatusCode)
+		switch result.Status {
+		case "down":
+			testCase.Failure = &junitMessage{Message: message, Type: "down", Text: result.ErrorMessage}
+			suite.Failures++
+			suites.Failures++
+		case "unreachable":
+			testCase.Skipped = &junitMessage{Message: result.ErrorMessage}
+			suite.Skipped++
+			suites.Skipped++
+		}
+		suite.Tests++
+		suites.Tests++
+		suite.Cases = append(suite.Cases, testCase)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	encoder := xml.NewEncoder(&buf)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suites); err != nil {
+		return err
+	}
+	buf.WriteByte('\n')
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func writeTextReport(w io.Writer, report ciReport) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "SITIO\tGRUPO\tESTADO\tCÓDIGO\tTIEMPO\tERROR")
+	for _, result := range report.Results {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%dms\t%s\n", result.SiteName, result.Group, result.Status,
+			result.StatusCode, result.ResponseTime, result.ErrorMessage)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "\n%d sitios verificados en %.1fs, %d con fallos\n", report.Total, report.Duration, report.Failed)
+	return err
+}
+
+// cliCI verifica todos los sitios una vez y falla si alguno está caído, para
+// usar después de un despliegue en un pipeline de CI
+func cliCI(args []string) int {
+	fs := newCLIFlagSet("ci")
+	format := fs.String("format", "text", "formato del resultado: text, json o junit")
+	output := fs.String("o", "", "archivo de salida (por defecto la salida estándar)")
+	noSave := fs.Bool("no-save", false, "no guardar los resultados en status.db ni enviar notificaciones")
+	group := fs.String("group", "", "solo los sitios de este grupo")
+	tag := fs.String("tag", "", "solo los sitios con esta etiqueta")
+	if _, err := parseCLIFlags(fs, args); err != nil {
+		return exitUsage
+	}
+
+	var write func(io.Writer, ciReport) error
+	switch *format {
+	case "text":
+		write = writeTextReport
+	case "json":
+		write = func(w io.Writer, report ciReport) error {
+			encoder := json.NewEncoder(w)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(report)
+		}
+	case "junit":
+		write = writeJUnitReport
+	default:
+		return cliUsageError("formato desconocido '%s' (text, json o junit)", *format)
+	}
+
+	// Sin -no-save se usa el servicio completo; si no, basta con la configuración
+	var s *StatusPageService
+	if *noSave {
+		s = &StatusPageService{}
+		if err := s.loadConfig(); err != nil {
+			return cliError(err)
+		}
+	} else {
+		s = NewStatusPageService()
+		defer closeCLIService(s)
+	}
+
+	var sites []Site
+	for _, site := range s.currentConfig().Sites {
+		if (*group == "" || site.Group == *group) && (*tag == "" || siteHasTag(site, *tag)) {
+			sites = append(sites, site)
+		}
+	}
+	if len(sites) == 0 {
+		return cliError(fmt.Errorf("no hay sitios que verificar"))
+	}
+
+	// El archivo se crea antes de verificar para no perder los checks (y sus
+	// notificaciones) por una ruta inválida. No se comprueba la conexión a
+	// internet: en CI los sitios suelen ser internos.
+	w := io.Writer(os.Stdout)
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return cliError(err)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	report := ciReport{StartedAt: time.Now().UTC(), Saved: !*noSave, Results: []ciResult{}}
+	for i, check := range s.checkOnce(sites, !*noSave) {
+		report.Results = append(report.Results, ciResult{Group: sites[i].Group, StatusCheck: check})
+		if isFailingStatus(check.Status) {
+			report.Failed++
+		}
+	}
+	report.Total = len(report.Results)
+	report.Duration = time.Since(report.StartedAt).Seconds()
+
+	if err := write(w, report); err != nil {
+		return cliError(err)
+	}
+
+	if report.Failed > 0 {
+		return exitFailure
+	}
+	return exitOK
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/xml"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// ciConfig escribe un config.json con un sitio operativo y otro caído
+func ciConfig(t *testing.T, s *StatusPageService, up, down string) {
+	t.Helper()
+	err := s.updateConfig(func(c *Config) error {
+		c.Sites = []Site{
+			{Name: "web", URL: up, Method: "GET", Timeout: 5, Group: "Frontend"},
+			{Name: "api", URL: down, Method: "GET", Timeout: 5, Group: "Backend"},
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCLICIJUnitReport(t *testing.T) {
+	s := newTestService(t)
+	up, _ := codeServer(t, http.StatusOK)
+	down, _ := codeServer(t, http.StatusServiceUnavailable)
+	ciConfig(t, s, up.URL, down.URL)
+
+	if code := cliCI([]string{"-no-save", "-format", "junit", "-o", "report.xml"}); code != exitFailure {
+		t.Fatalf("código de salida = %d, se esperaba %d", code, exitFailure)
+	}
+
+	data, err := os.ReadFile("report.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report junitTestSuites
+	if err := xml.Unmarshal(data, &report); err != nil {
+		t.Fatal(err)
+	}
+	if report.Tests != 2 || report.Failures != 1 || len(report.Suites) != 2 {
+		t.Errorf("informe = %+v", report)
+	}
+	for _, suite := range report.Suites {
+		if suite.Name == "Backend" && (suite.Failures != 1 || suite.Cases[0].Failure == nil) {
+			t.Errorf("suite Backend = %+v", suite)
+		}
+	}
+
+	if code := cliCI([]string{"-no-save", "-group", "Frontend", "-o", "report.txt"}); code != exitOK {
+		t.Errorf("solo Frontend: código de salida = %d, se esperaba %d", code, exitOK)
+	}
+}
+
+// Con una ruta de salida inválida no se verifica ningún sitio
+func TestCLICIInvalidOutput(t *testing.T) {
+	s := newTestService(t)
+	up, upRequests := codeServer(t, http.StatusOK)
+	down, downRequests := codeServer(t, http.StatusServiceUnavailable)
+	ciConfig(t, s, up.URL, down.URL)
+
+	output := filepath.Join("no-existe", "report.xml")
+	if code := cliCI([]string{"-no-save", "-o", output}); code != exitFailure {
+		t.Errorf("código de salida = %d, se esperaba %d", code, exitFailure)
+	}
+	if upRequests()+downRequests() != 0 {
+		t.Error("no deben verificarse los sitios si no se puede escribir el informe")
+	}
+}
@@ -0,0 +1,533 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Códigos de salida de la línea de comandos
+const (
+	exitOK      = 0
+	exitFailure = 1 // algún sitio caído o error
+	exitUsage   = 2
+)
+
+// Subcomandos de la línea de comandos; sin subcomando se inicia la aplicación
+var cliCommands = map[string]func(args []string) int{
+	"sites":  cliSites,
+	"check":  cliCheck,
+	"ci":     cliCI,
+	"status": cliStatus,
+	"stats":  cliStats,
+	"export": cliExport,
+	"serve":  cliServe,
+}
+
+const cliUsageText = `Subcomandos (usan el config.json y status.db del directorio actual):
+  sites list [-tag T] [-json]                         listar los sitios configurados
+  sites add <nombre> <url> [opciones]                 agregar un sitio
+  sites update <nombre> [-url U] [opciones]           modificar un sitio
+  sites remove <nombre>                               eliminar un sitio y su historial
+  check <sitio> [-json]                               verificar un sitio y guardar el resultado
+  ci [opciones]                                       verificar todos los sitios una vez
+  status [-group G] [-json]                           último estado de cada sitio
+  stats [-days N] [-json]                             disponibilidad de los últimos N días
+  export sites [-o archivo]                           exportar sitios y grupos en JSON
+  export history [-format F] [-site S] [-from] [-to]  exportar el historial de checks (F: csv, json, ndjson)
+  serve [-http dirección]                             ejecutar el monitoreo sin interfaz gráfica
+
+Opciones de sites add/update: -method, -timeout, -group, -tags, -severity, -depends
+Opciones de ci: -format text|json|junit, -group G, -tag T, -no-save (sin guardar ni notificar), -o archivo
+
+check, ci y status terminan con código 1 si algún sitio está caído; 2 indica un uso incorrecto.
+`
+
+func cliUsage(w io.Writer) {
+	fmt.Fprint(w, cliUsageText)
+}
+
+// runCLI ejecuta el subcomando indicado y devuelve el código de salida
+func runCLI(name string, args []string) int {
+	return cliCommands[name](args)
+}
+
+// parseCLIFlags permite mezclar opciones y argumentos (ej. "check api -json");
+// después de "--" todo se trata como argumento (ej. un nombre que empieza por "-")
+func parseCLIFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		rest := fs.Args()
+		if len(rest) == 0 {
+			return positional, nil
+		}
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			return append(positional, rest...), nil
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
+
+func newCLIFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Uso de %s:\n", name)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// closeCLIService espera las notificaciones en curso y cierra la base de datos
+func closeCLIService(s *StatusPageService) {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if !s.waitInFlight(ctx) {
+		fmt.Fprintln(os.Stderr, "aviso: quedaron notificaciones sin enviar")
+	}
+	s.db.Close()
+}
+
+func cliError(err error) int {
+	fmt.Fprintln(os.Stderr, "error:", err)
+	return exitFailure
+}
+
+func cliUsageError(format string, args ...any) int {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	return exitUsage
+}
+
+func printJSON(v any) int {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return cliError(err)
+	}
+	return exitOK
+}
+
+// isFailingStatus indica si el estado debe hacer fallar check y status
+func isFailingStatus(status string) bool {
+	return status == "down" || status == "unreachable"
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func cliSites(args []string) int {
+	if len(args) == 0 {
+		return cliUsageError("falta la acción de sites (list, add, update o remove)")
+	}
+
+	switch args[0] {
+	case "list":
+		return cliSitesList(args[1:])
+	case "add", "update":
+		return cliSitesSave(args[0], args[1:])
+	case "remove":
+		return cliSitesRemove(args[1:])
+	}
+	return cliUsageError("acción de sites desconocida '%s'", args[0])
+}
+
+func cliSitesList(args []string) int {
+	fs := newCLIFlagSet("sites list")
+	tag := fs.String("tag", "", "solo los sitios con esta etiqueta")
+	asJSON := fs.Bool("json", false, "salida en JSON")
+	if _, err := parseCLIFlags(fs, args); err != nil {
+		return exitUsage
+	}
+
+	s := NewStatusPageService()
+	defer closeCLIService(s)
+
+	sites, err := s.GetAllSites(*tag)
+	if err != nil {
+		return cliError(err)
+	}
+	if *asJSON {
+		if sites == nil {
+			sites = []SiteDetail{}
+		}
+		return printJSON(sites)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NOMBRE\tURL\tMÉTODO\tTIMEOUT\tGRUPO\tETIQUETAS\tDEPENDE DE")
+	for _, site := range sites {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%ds\t%s\t%s\t%s\n", site.Name, site.URL, site.Method, site.Timeout,
+			site.Group, strings.Join(site.Tags, ","), strings.Join(site.DependsOn, ","))
+	}
+	tw.Flush()
+	return exitOK
+}
+
+// cliSitesSave implementa "sites add" y "sites update"; en update solo se
+// modifican las opciones indicadas
+func cliSitesSave(action string, args []string) int {
+	fs := newCLIFlagSet("sites " + action)
+	siteURL := fs.String("url", "", "URL del sitio (http:// o https://)")
+	method := fs.String("method", "", "método HTTP (GET por defecto)")
+	timeout := fs.Int("timeout", 0, "timeout en segundos (10 por defecto)")
+	group := fs.String("group", "", "grupo del sitio")
+	tags := fs.String("tags", "", "etiquetas separadas por comas")
+	severity := fs.String("severity", "", "severidad: critical, normal o low")
+	depends := fs.String("depends", "", "sitios de los que depende, separados por comas")
+	positional, err := parseCLIFlags(fs, args)
+	if err != nil {
+		return exitUsage
+	}
+
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	var req apiSiteRequest
+	switch {
+	case action == "add" && len(positional) == 2 && !set["url"]:
+		req = apiSiteRequest{Name: positional[0], URL: positional[1]}
+	case action == "add" && len(positional) == 1 && set["url"]:
+		req = apiSiteRequest{Name: positional[0], URL: *siteURL}
+	case action == "update" && len(positional) == 1:
+		req = apiSiteRequest{Name: positional[0]}
+	default:
+		if action == "add" {
+			return cliUsageError("uso: sites add <nombre> <url> [opciones]")
+		}
+		return cliUsageError("uso: sites update <nombre> [opciones]")
+	}
+
+	if set["severity"] && *severity != SeverityCritical && *severity != SeverityNormal && *severity != SeverityLow {
+		return cliError(fmt.Errorf("severidad desconocida '%s'", *severity))
+	}
+
+	s := NewStatusPageService()
+	defer closeCLIService(s)
+
+	current, exists := s.findSite(req.Name)
+	if action == "add" && exists {
+		return cliError(fmt.Errorf("el sitio '%s' ya existe", req.Name))
+	}
+	if action == "update" {
+		if !exists {
+			return cliError(fmt.Errorf("sitio '%s' no encontrado", req.Name))
+		}
+		req.URL, req.Method, req.Timeout = current.URL, current.Method, current.Timeout
+		if set["url"] {
+			req.URL = *siteURL
+		}
+	}
+	if set["method"] {
+		req.Method = *method
+	}
+	if set["timeout"] {
+		req.Timeout = *timeout
+		if req.Timeout == 0 {
+			return cliError(fmt.Errorf("timeout debe estar entre 1 y 300 segundos"))
+		}
+	}
+	if err := validateSiteRequest(&req); err != nil {
+		return cliError(err)
+	}
+
+	// Todos los cambios se validan y se guardan juntos: un error no deja el sitio a medias
+	err = s.updateConfig(func(c *Config) error {
+		i := slices.IndexFunc(c.Sites, func(site Site) bool { return site.Name == req.Name })
+		switch {
+		case action == "add" && i >= 0:
+			return fmt.Errorf("el sitio '%s' ya existe", req.Name)
+		case action == "add":
+			c.Sites = append(c.Sites, Site{Name: req.Name})
+			i = len(c.Sites) - 1
+		case i < 0:
+			return fmt.Errorf("sitio '%s' no encontrado", req.Name)
+		}
+
+		site := &c.Sites[i]
+		site.URL, site.Method, site.Timeout = req.URL, req.Method, req.Timeout
+		if set["group"] {
+			site.Group = strings.TrimSpace(*group)
+		}
+		if set["tags"] {
+			site.Tags = normalizeTags(splitList(*tags))
+		}
+		if set["severity"] {
+			site.Severity = *severity
+		}
+		if set["depends"] {
+			site.DependsOn = splitList(*depends)
+			for _, dep := range site.DependsOn {
+				if !slices.ContainsFunc(c.Sites, func(other Site) bool { return other.Name == dep }) {
+					return fmt.Errorf("dependencia '%s': sitio no encontrado", dep)
+				}
+			}
+			if err := validateDependencies(c.Sites); err != nil {
+				return err
+			}
+		}
+		c.Groups = normalizeGroups(c.Groups, c.Sites)
+		return nil
+	})
+	if err != nil {
+		return cliError(err)
+	}
+
+	if action == "add" {
+		fmt.Printf("Sitio '%s' agregado\n", req.Name)
+	} else {
+		fmt.Printf("Sitio '%s' actualizado\n", req.Name)
+	}
+	return exitOK
+}
+
+func cliSitesRemove(args []string) int {
+	fs := newCLIFlagSet("sites remove")
+	positional, err := parseCLIFlags(fs, args)
+	if err != nil {
+		return exitUsage
+	}
+	if len(positional) != 1 {
+		return cliUsageError("uso: sites remove <nombre>")
+	}
+
+	s := NewStatusPageService()
+	defer closeCLIService(s)
+
+	if _, exists := s.findSite(positional[0]); !exists {
+		return cliError(fmt.Errorf("sitio '%s' no encontrado", positional[0]))
+	}
+	if err := s.RemoveSite(positional[0]); err != nil {
+		return cliError(err)
+	}
+	fmt.Printf("Sitio '%s' eliminado\n", positional[0])
+	return exitOK
+}
+
+func cliCheck(args []string) int {
+	fs := newCLIFlagSet("check")
+	asJSON := fs.Bool("json", false, "salida en JSON")
+	positional, err := parseCLIFlags(fs, args)
+	if err != nil {
+		return exitUsage
+	}
+	if len(positional) != 1 {
+		return cliUsageError("uso: check <sitio> [-json]")
+	}
+
+	s := NewStatusPageService()
+	defer closeCLIService(s)
+
+	site, ok := s.findSite(positional[0])
+	if !ok {
+		return cliError(fmt.Errorf("sitio '%s' no encontrado", positional[0]))
+	}
+	// Igual que en los checks programados: sin internet no se registran caídas falsas
+	if !s.hasInternetConnection() {
+		return cliError(fmt.Errorf("sin conexión a internet, no se verificó el sitio"))
+	}
+
+	check := s.checkSite(site)
+	if *asJSON {
+		if code := printJSON(check); code != exitOK {
+			return code
+		}
+	} else {
+		fmt.Printf("%s: %s", check.SiteName, check.Status)
+		if check.StatusCode > 0 {
+			fmt.Printf(" (%d)", check.StatusCode)
+		}
+		fmt.Printf(" %dms\n", check.ResponseTime)
+		if check.ErrorMessage != "" {
+			fmt.Println(check.ErrorMessage)
+		}
+	}
+
+	if isFailingStatus(check.Status) {
+		return exitFailure
+	}
+	return exitOK
+}
+
+func cliStatus(args []string) int {
+	fs := newCLIFlagSet("status")
+	group := fs.String("group", "", "solo los sitios de este grupo")
+	asJSON := fs.Bool("json", false, "salida en JSON")
+	if _, err := parseCLIFlags(fs, args); err != nil {
+		return exitUsage
+	}
+
+	s := NewStatusPageService()
+	defer closeCLIService(s)
+
+	all, err := s.GetAllSites("")
+	if err != nil {
+		return cliError(err)
+	}
+	sites := []SiteDetail{}
+	failing := false
+	for _, site := range all {
+		if *group != "" && site.Group != *group {
+			continue
+		}
+		sites = append(sites, site)
+		failing = failing || isFailingStatus(site.Status)
+	}
+
+	if *asJSON {
+		if code := printJSON(sites); code != exitOK {
+			return code
+		}
+	} else {
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "SITIO\tGRUPO\tESTADO\tCÓDIGO\tTIEMPO\tÚLTIMO CHECK\tERROR")
+		for _, site := range sites {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%dms\t%s\t%s\n", site.Name, site.Group, site.Status,
+				site.StatusCode, site.ResponseTime, site.LastChecked, site.ErrorMessage)
+		}
+		tw.Flush()
+	}
+
+	if failing {
+		return exitFailure
+	}
+	return exitOK
+}
+
+// Disponibilidad de un sitio en el periodo consultado por "stats"
+type cliSiteStats struct {
+	SiteName          string  `json:"siteName"`
+	Days              int     `json:"days"`
+	TotalChecks       int     `json:"totalChecks"`
+	UpChecks          int     `json:"upChecks"`
+	DownChecks        int     `json:"downChecks"`
+	UnreachableChecks int     `json:"unreachableChecks"`
+	UptimePercent     float64 `json:"uptimePercent"`
+}
+
+func cliStats(args []string) int {
+	fs := newCLIFlagSet("stats")
+	days := fs.Int("days", 30, "días a incluir")
+	asJSON := fs.Bool("json", false, "salida en JSON")
+	if _, err := parseCLIFlags(fs, args); err != nil {
+		return exitUsage
+	}
+	if *days < 1 || *days > dailyStatsRetentionDays {
+		return cliUsageError("-days debe estar entre 1 y %d", dailyStatsRetentionDays)
+	}
+
+	s := NewStatusPageService()
+	defer closeCLIService(s)
+
+	stats := []cliSiteStats{}
+	for _, site := range s.currentConfig().Sites {
+		daily, err := s.siteDailyStats(site.Name, *days)
+		if err != nil {
+			return cliError(err)
+		}
+		total := cliSiteStats{SiteName: site.Name, Days: *days}
+		for _, day := range daily {
+			total.TotalChecks += day.TotalChecks
+			total.UpChecks += day.UpChecks
+			total.DownChecks += day.DownChecks
+			total.UnreachableChecks += day.UnreachableChecks
+		}
+		if total.TotalChecks > 0 {
+			total.UptimePercent = float64(total.UpChecks) / float64(total.TotalChecks) * 100
+		}
+		stats = append(stats, total)
+	}
+
+	if *asJSON {
+		return printJSON(stats)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "SITIO\tCHECKS\tUP\tDOWN\tUNREACHABLE\tDISPONIBILIDAD\t")
+	for _, stat := range stats {
+		uptime := "-"
+		if stat.TotalChecks > 0 {
+			uptime = fmt.Sprintf("%.2f%%", stat.UptimePercent)
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\t%s\t\n", stat.SiteName, stat.TotalChecks, stat.UpChecks,
+			stat.DownChecks, stat.UnreachableChecks, uptime)
+	}
+	tw.Flush()
+	return exitOK
+}
+
+// Sitios y grupos exportados con "export sites" (sin credenciales de notificaciones)
+type cliSitesExport struct {
+	ExportedAt time.Time `json:"exportedAt"`
+	Groups     []Group   `json:"groups,omitempty"`
+	Sites      []Site    `json:"sites"`
+}
+
+func cliExport(args []string) int {
+	if len(args) > 0 && args[0] == "history" {
+		return cliExportHistory(args[1:])
+	}
+	if len(args) == 0 || args[0] != "sites" {
+		return cliUsageError("uso: export sites|history [opciones]")
+	}
+
+	fs := newCLIFlagSet("export sites")
+	output := fs.String("o", "", "archivo de salida (por defecto la salida estándar)")
+	if _, err := parseCLIFlags(fs, args[1:]); err != nil {
+		return exitUsage
+	}
+
+	s := NewStatusPageService()
+	defer closeCLIService(s)
+
+	config := s.currentConfig()
+	data, err := json.MarshalIndent(cliSitesExport{
+		ExportedAt: time.Now().UTC(),
+		Groups:     config.Groups,
+		Sites:      config.Sites,
+	}, "", "  ")
+	if err != nil {
+		return cliError(err)
+	}
+	data = append(data, '\n')
+
+	if *output == "" {
+		os.Stdout.Write(data)
+		return exitOK
+	}
+	if err := os.WriteFile(*output, data, 0644); err != nil {
+		return cliError(err)
+	}
+	return exitOK
+}
+
+func cliServe(args []string) int {
+	fs := newCLIFlagSet("serve")
+	httpAddr := fs.String("http", "", "habilitar la API REST en la dirección indicada (ej. 127.0.0.1:8420)")
+	if _, err := parseCLIFlags(fs, args); err != nil {
+		return exitUsage
+	}
+
+	s := NewStatusPageService()
+	if *httpAddr != "" {
+		s.config.HTTP = HTTPServerConfig{Enabled: true, Address: *httpAddr}
+	}
+	runHeadless(s)
+	return exitOK
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"flag"
+	"slices"
+	"testing"
+)
+
+func TestParseCLIFlags(t *testing.T) {
+	tests := []struct {
+		args       []string
+		positional []string
+		json       bool
+	}{
+		{[]string{"api", "-json"}, []string{"api"}, true},
+		{[]string{"-json", "api", "web"}, []string{"api", "web"}, true},
+		{[]string{"--", "-api"}, []string{"-api"}, false},
+		{[]string{"api", "--", "-json", "web"}, []string{"api", "-json", "web"}, false},
+	}
+
+	for _, tt := range tests {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		jsonOutput := fs.Bool("json", false, "")
+		positional, err := parseCLIFlags(fs, tt.args)
+		if err != nil {
+			t.Fatalf("%q: %v", tt.args, err)
+		}
+		if !slices.Equal(positional, tt.positional) || *jsonOutput != tt.json {
+			t.Errorf("%q: argumentos = %q, json = %v", tt.args, positional, *jsonOutput)
+		}
+	}
+}
+
+// Un error en cualquier opción no deja el sitio agregado o modificado a medias
+func TestCLISitesSaveIsAtomic(t *testing.T) {
+	s := newTestService(t)
+	err := s.updateConfig(func(c *Config) error {
+		c.Sites = []Site{{Name: "web", URL: "https://example.com", Method: "GET", Timeout: 5}}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	invalid := [][]string{
+		{"add", "api", "https://api.example.com", "-group", "Backend", "-depends", "no-existe"},
+		{"add", "api", "https://api.example.com", "-depends", "api"},
+		{"update", "web", "-group", "Frontend", "-depends", "no-existe"},
+		{"update", "web", "-tags", "prod", "-severity", "urgente"},
+	}
+	for _, args := range invalid {
+		if code := cliSitesSave(args[0], args[1:]); code != exitFailure {
+			t.Errorf("%q: código de salida = %d, se esperaba %d", args, code, exitFailure)
+		}
+	}
+
+	reloaded := &StatusPageService{}
+	if err := reloaded.loadConfig(); err != nil {
+		t.Fatal(err)
+	}
+	sites := reloaded.currentConfig().Sites
+	if len(sites) != 1 || sites[0].Group != "" || len(sites[0].Tags) != 0 || len(sites[0].DependsOn) != 0 {
+		t.Fatalf("sitios = %+v, no debía cambiar nada", sites)
+	}
+
+	if code := cliSitesSave("add", []string{"api", "https://api.example.com", "-group", "Backend", "-tags", "prod", "-severity", "critical", "-depends", "web"}); code != exitOK {
+		t.Fatalf("código de salida = %d", code)
+	}
+	if err := reloaded.loadConfig(); err != nil {
+		t.Fatal(err)
+	}
+	api, ok := reloaded.findSite("api")
+	if !ok || api.Group != "Backend" || !slices.Equal(api.Tags, []string{"prod"}) ||
+		api.Severity != SeverityCritical || !slices.Equal(api.DependsOn, []string{"web"}) {
+		t.Errorf("sitio = %+v", api)
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Días que se conservan las estadísticas diarias agregadas
+const dailyStatsRetentionDays = 400
+
+// initDailyStatsDB crea la tabla con el agregado diario de los checks que
+// ya se eliminaron por la retención (usada por las barras de 90 días)
+func (s *StatusPageService) initDailyStatsDB() error {
+	createTableSQL := `
+	CREATE TABLE IF NOT EXISTS daily_stats (
+		site_name TEXT NOT NULL,
+		date TEXT NOT NULL,
+		total_checks INTEGER NOT NULL DEFAULT 0,
+		up_checks INTEGER NOT NULL DEFAULT 0,
+		down_checks INTEGER NOT NULL DEFAULT 0,
+		unreachable_checks INTEGER NOT NULL DEFAULT 0,
+		PRIMARY KEY (site_name, date)
+	);
+	`
+
+	_, err := s.db.Exec(createTableSQL)
+	return err
+}
+
+// rollupDailyStats suma al agregado diario los checks anteriores a cutoff.
+// Debe ejecutarse en la misma transacción que los elimina para no contarlos dos veces.
+func rollupDailyStats(tx *sql.Tx, cutoff time.Time) error {
+	_, err := tx.Exec(`
+	INSERT INTO daily_stats (site_name, date, total_checks, up_checks, down_checks, unreachable_checks)
+	SELECT site_name, DATE(checked_at),
+		   SUM(CASE WHEN status IN ('up', 'down') THEN 1 ELSE 0 END),
+		   SUM(CASE WHEN status = 'up' THEN 1 ELSE 0 END),
+		   SUM(CASE WHEN status = 'down' THEN 1 ELSE 0 END),
+		   SUM(CASE WHEN status = 'unreachable' THEN 1 ELSE 0 END)
+	FROM status_checks
+	WHERE checked_at < ?
+	GROUP BY site_name, DATE(checked_at)
+	ON CONFLICT(site_name, date) DO UPDATE SET
+		total_checks = total_checks + excluded.total_checks,
+		up_checks = up_checks + excluded.up_checks,
+		down_checks = down_checks + excluded.down_checks,
+		unreachable_checks = unreachable_checks + excluded.unreachable_checks
+	`, cutoff)
+	return err
+}
+
+// siteDailyStats devuelve las estadísticas por día de los últimos días (más reciente primero),
+// combinando los checks guardados con el agregado de los ya eliminados
+func (s *StatusPageService) siteDailyStats(siteName string, days int) ([]DailyStats, error) {
+	since := fmt.Sprintf("-%d days", days)
+	query := `
+	SELECT check_date, SUM(total_checks), SUM(up_checks), SUM(down_checks), SUM(unreachable_checks)
+	FROM (
+		SELECT DATE(checked_at) as check_date,
+			   SUM(CASE WHEN status IN ('up', 'down') THEN 1 ELSE 0 END) as total_checks,
+			   SUM(CASE WHEN status = 'up' THEN 1 ELSE 0 END) as up_checks,
+			   SUM(CASE WHEN status = 'down' THEN 1 ELSE 0 END) as down_checks,
+			   SUM(CASE WHEN status = 'unreachable' THEN 1 ELSE 0 END) as unreachable_checks
+		FROM status_checks
+		WHERE site_name = ? AND checked_at >= DATE('now', ?)
+		GROUP BY DATE(checked_at)
+
+		UNION ALL
+
+		SELECT date, total_checks, up_checks, down_checks, unreachable_checks
+		FROM daily_stats
+		WHERE site_name = ? AND date >= DATE('now', ?)
+	)
+	GROUP BY check_date
+	ORDER BY check_date DESC
+	`
+
+	rows, err := s.db.Query(query, siteName, since, siteName, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stats []DailyStats
+	for rows.Next() {
+		var stat DailyStats
+		if err := rows.Scan(&stat.Date, &stat.TotalChecks, &stat.UpChecks, &stat.DownChecks, &stat.UnreachableChecks); err != nil {
+			return nil, err
+		}
+		if stat.TotalChecks > 0 {
+			stat.UptimePercent = float64(stat.UpChecks) / float64(stat.TotalChecks) * 100
+		}
+		stats = append(stats, stat)
+	}
+
+	return stats, rows.Err()
+}
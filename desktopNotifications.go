@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// Configuración de las notificaciones de escritorio
+type DesktopNotificationConfig struct {
+	DoNotDisturb bool `json:"doNotDisturb"` // silencia todas las notificaciones de escritorio
+}
+
+// OnTransition registra una función que se ejecuta en cada caída o
+// recuperación de un sitio (ej. notificaciones de escritorio en la GUI)
+func (s *StatusPageService) OnTransition(listener func(NotificationEvent)) {
+	s.listenersMu.Lock()
+	defer s.listenersMu.Unlock()
+	s.transitionListeners = append(s.transitionListeners, listener)
+}
+
+func (s *StatusPageService) emitTransition(event NotificationEvent) {
+	s.publishTransition(event)
+
+	s.listenersMu.Lock()
+	listeners := append([]func(NotificationEvent){}, s.transitionListeners...)
+	s.listenersMu.Unlock()
+
+	for _, listener := range listeners {
+		go listener(event)
+	}
+}
+
+// desktopNotificationAllowed indica si el evento debe mostrarse como
+// notificación de escritorio según "no molestar" y el silencio por sitio
+func (s *StatusPageService) desktopNotificationAllowed(event NotificationEvent) bool {
+	if s.currentConfig().Desktop.DoNotDisturb {
+		return false
+	}
+	// Mientras el sitio oscila solo se muestran las alertas de oscilación
+	if event.Flapping && (event.Type == EventDown || event.Type == EventRecovery) {
+		return false
+	}
+	if site, ok := s.findSite(event.Site.Name); ok && site.MuteDesktop {
+		return false
+	}
+	return true
+}
+
+// NotifyDesktop muestra el evento como notificación nativa si está permitido
+func (s *StatusPageService) NotifyDesktop(event NotificationEvent) {
+	if !s.desktopNotificationAllowed(event) {
+		return
+	}
+	if err := sendDesktopNotification(eventTitle(event), event.Summary()); err != nil {
+		log.Printf("Error mostrando notificación de escritorio: %v", err)
+	}
+}
+
+// SetDoNotDisturb activa o desactiva el modo "no molestar"
+func (s *StatusPageService) SetDoNotDisturb(enabled bool) error {
+	return s.updateConfig(func(c *Config) error {
+		c.Desktop.DoNotDisturb = enabled
+		return nil
+	})
+}
+
+func (s *StatusPageService) GetDoNotDisturb() bool {
+	return s.currentConfig().Desktop.DoNotDisturb
+}
+
+// SetSiteDesktopMute silencia las notificaciones de escritorio de un sitio
+func (s *StatusPageService) SetSiteDesktopMute(name string, muted bool) error {
+	return s.updateSite(name, func(c *Config, site *Site) error {
+		site.MuteDesktop = muted
+		return nil
+	})
+}
+
+// sendDesktopNotification muestra una notificación nativa del sistema operativo.
+// Los textos se pasan como argumentos o variables de entorno para no
+// interpretarlos como código.
+func sendDesktopNotification(title, message string) error {
+	var cmd *exec.Cmd
+
+	switch runtime.GOOS {
+	case "linux":
+		cmd = exec.Command("notify-send", "--app-name=StatusPage Monitor", title, message)
+	case "darwin":
+		cmd = exec.Command("osascript",
+			"-e", "on run argv",
+			"-e", "display notification (item 2 of argv) with title (item 1 of argv)",
+			"-e", "end run",
+			title, message)
+	case "windows":
+		script := `[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] > $null
+$template = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02)
+$texts = $template.GetElementsByTagName('text')
+$texts.Item(0).AppendChild($template.CreateTextNode($env:STATUSPAGE_TITLE)) > $null
+$texts.Item(1).AppendChild($template.CreateTextNode($env:STATUSPAGE_MESSAGE)) > $null
+$toast = [Windows.UI.Notifications.ToastNotification]::new($template)
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier('StatusPage Monitor').Show($toast)`
+		cmd = exec.Command("powershell", "-NoProfile", "-NonInteractive", "-WindowStyle", "Hidden", "-Command", script)
+		cmd.Env = append(os.Environ(), "STATUSPAGE_TITLE="+title, "STATUSPAGE_MESSAGE="+message)
+	default:
+		return fmt.Errorf("notificaciones de escritorio no soportadas en %s", runtime.GOOS)
+	}
+
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%v: %s", err, out)
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	htmltemplate "html/template"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Configuración de un canal de correo SMTP
+type EmailConfig struct {
+	Host       string   `json:"host"`
+	Port       int      `json:"port"`               // 25, 587 (STARTTLS) o 465 (TLS)
+	Security   string   `json:"security,omitempty"` // "none", "starttls", "tls"
+	Username   string   `json:"username,omitempty"`
+	Password   string   `json:"password,omitempty"`
+	SkipVerify bool     `json:"skipVerify,omitempty"` // no verificar el certificado del servidor
+	From       string   `json:"from"`
+	To         []string `json:"to"`
+}
+
+type EmailNotifier struct {
+	config    EmailConfig
+	templates *messageTemplates
+	// El mensaje usa una plantilla propia: la parte HTML la muestra tal cual
+	customMessage bool
+}
+
+// Datos de la parte HTML del correo
+type emailHTMLData struct {
+	notificationTemplateData
+	Message       string
+	CustomMessage bool
+}
+
+// Plantilla por defecto del cuerpo en texto plano
+const defaultEmailTextTemplate = `{{.Summary}}
+
+Sitio:       {{.Site.Name}}
+URL:         {{.Site.URL}}
+{{- if .Site.Group}}
+Grupo:       {{.Site.Group}}
+{{- end}}
+Estado:      {{.Check.Status}}
+Código HTTP: {{.Check.StatusCode}}
+{{- if .Check.ErrorMessage}}
+Error:       {{.Check.ErrorMessage}}
+{{- else if .Incident.ErrorMessage}}
+Error:       {{.Incident.ErrorMessage}}
+{{- end}}
+{{- if not .Incident.StartedAt.IsZero}}
+Inicio:      {{.Incident.StartedAt.Format "2006-01-02 15:04:05 MST"}}
+Duración:    {{.Duration}}
+{{- end}}
+`
+
+var emailHTMLTemplate = htmltemplate.Must(htmltemplate.New("html").Parse(`<!DOCTYPE html>
+<html>
+<body style="font-family: Arial, sans-serif; color: #1f2937;">
+  <h2 style="color: {{if .IsResolved}}#16a34a{{else}}#dc2626{{end}};">{{.Title}}</h2>
+  {{- if .CustomMessage}}
+  <pre style="font-family: inherit; white-space: pre-wrap;">{{.Message}}</pre>
+  {{- else}}
+  <p>{{.Summary}}</p>
+  <table cellpadding="4" style="border-collapse: collapse;">
+    <tr><td><strong>Sitio</strong></td><td>{{.Site.Name}}</td></tr>
+    <tr><td><strong>URL</strong></td><td><a href="{{.Site.URL}}">{{.Site.URL}}</a></td></tr>
+    {{- if .Site.Group}}
+    <tr><td><strong>Grupo</strong></td><td>{{.Site.Group}}</td></tr>
+    {{- end}}
+    <tr><td><strong>Estado</strong></td><td>{{.Check.Status}}</td></tr>
+    <tr><td><strong>Código HTTP</strong></td><td>{{.Check.StatusCode}}</td></tr>
+    {{- if .Check.ErrorMessage}}
+    <tr><td><strong>Error</strong></td><td>{{.Check.ErrorMessage}}</td></tr>
+    {{- else if .Incident.ErrorMessage}}
+    <tr><td><strong>Error</strong></td><td>{{.Incident.ErrorMessage}}</td></tr>
+    {{- end}}
+    {{- if not .Incident.StartedAt.IsZero}}
+    <tr><td><strong>Inicio</strong></td><td>{{.Incident.StartedAt.Format "2006-01-02 15:04:05 MST"}}</td></tr>
+    <tr><td><strong>Duración</strong></td><td>{{.Duration}}</td></tr>
+    {{- end}}
+  </table>
+  {{- end}}
+</body>
+</html>
+`))
+
+// buildMessage genera el mensaje MIME multipart/alternative con texto plano y HTML
+func (e *EmailNotifier) buildMessage(event NotificationEvent) ([]byte, error) {
+	rendered, err := e.templates.render(event)
+	if err != nil {
+		return nil, err
+	}
+
+	data := emailHTMLData{
+		notificationTemplateData: notificationTemplateData{
+			NotificationEvent: event,
+			Duration:          event.Duration(),
+			Title:             rendered.Title,
+		},
+		Message:       rendered.Message,
+		CustomMessage: e.customMessage,
+	}
+
+	var html bytes.Buffer
+	if err := emailHTMLTemplate.Execute(&html, data); err != nil {
+		return nil, &notificationRenderError{err}
+	}
+	text := []byte(rendered.Message + "\n")
+
+	boundary, err := randomBoundary()
+	if err != nil {
+		return nil, err
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", e.config.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(e.config.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", rendered.Title))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", boundary)
+
+	for _, part := range []struct {
+		contentType string
+		body        []byte
+	}{
+		{"text/plain", text},
+		{"text/html", html.Bytes()},
+	} {
+		fmt.Fprintf(&msg, "--%s\r\n", boundary)
+		fmt.Fprintf(&msg, "Content-Type: %s; charset=utf-8\r\n", part.contentType)
+		msg.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+		qp := quotedprintable.NewWriter(&msg)
+		qp.Write(part.body)
+		qp.Close()
+		msg.WriteString("\r\n")
+	}
+	fmt.Fprintf(&msg, "--%s--\r\n", boundary)
+
+	return msg.Bytes(), nil
+}
+
+func randomBoundary() (string, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "statuspage-" + hex.EncodeToString(b), nil
+}
+
+func (e *EmailNotifier) Send(ctx context.Context, event NotificationEvent) (string, error) {
+	if len(e.config.To) == 0 {
+		return "", fmt.Errorf("no hay destinatarios configurados")
+	}
+
+	msg, err := e.buildMessage(event)
+	if err != nil {
+		return "", err
+	}
+
+	return string(msg), e.sendMail(ctx, msg)
+}
+
+func (e *EmailNotifier) sendMail(ctx context.Context, msg []byte) error {
+	port := e.config.Port
+	if port == 0 {
+		port = 25
+	}
+	addr := net.JoinHostPort(e.config.Host, strconv.Itoa(port))
+	tlsConfig := &tls.Config{ServerName: e.config.Host, InsecureSkipVerify: e.config.SkipVerify}
+
+	dialer := &net.Dialer{Timeout: 15 * time.Second}
+	var conn net.Conn
+	var err error
+	if e.config.Security == "tls" {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, tlsConfig)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, e.config.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if e.config.Security == "starttls" {
+		if err := client.StartTLS(tlsConfig); err != nil {
+			return fmt.Errorf("STARTTLS: %w", err)
+		}
+	}
+
+	if e.config.Username != "" {
+		auth := smtp.PlainAuth("", e.config.Username, e.config.Password, e.config.Host)
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("autenticación SMTP: %w", err)
+		}
+	}
+
+	if err := client.Mail(e.config.From); err != nil {
+		return err
+	}
+	for _, to := range e.config.To {
+		if err := client.Rcpt(to); err != nil {
+			return fmt.Errorf("destinatario %s: %w", to, err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
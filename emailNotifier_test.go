@@ -0,0 +1,223 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// smtpServer es un servidor SMTP mínimo que guarda los mensajes recibidos y
+// rechaza los destinatarios que empiezan por "rechazado"
+type smtpServer struct {
+	listener net.Listener
+	mu       sync.Mutex
+	auth     string
+	from     string
+	rcpts    []string
+	data     string
+}
+
+func newSMTPServer(t *testing.T) *smtpServer {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := &smtpServer{listener: listener}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go srv.serve(conn)
+		}
+	}()
+	return srv
+}
+
+func (srv *smtpServer) port() int {
+	return srv.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (srv *smtpServer) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+
+	reply("220 localhost ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		command := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+
+		srv.mu.Lock()
+		switch command {
+		case "EHLO", "HELO":
+			reply("250-localhost")
+			reply("250 AUTH PLAIN")
+		case "AUTH":
+			decoded, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(line, "AUTH PLAIN "))
+			srv.auth = string(decoded)
+			reply("235 autenticado")
+		case "MAIL":
+			srv.from = strings.Trim(strings.TrimPrefix(line, "MAIL FROM:"), "<>")
+			reply("250 OK")
+		case "RCPT":
+			rcpt := strings.Trim(strings.TrimPrefix(line, "RCPT TO:"), "<>")
+			if strings.HasPrefix(rcpt, "rechazado") {
+				reply("550 buzón inexistente")
+				break
+			}
+			srv.rcpts = append(srv.rcpts, rcpt)
+			reply("250 OK")
+		case "DATA":
+			reply("354 fin con <CRLF>.<CRLF>")
+			var data strings.Builder
+			for {
+				line, err := r.ReadString('\n')
+				if err != nil {
+					srv.mu.Unlock()
+					return
+				}
+				if line == ".\r\n" {
+					break
+				}
+				data.WriteString(strings.TrimPrefix(line, "."))
+			}
+			srv.data = data.String()
+			reply("250 OK")
+		case "QUIT":
+			reply("221 adiós")
+			srv.mu.Unlock()
+			return
+		default:
+			reply("250 OK")
+		}
+		srv.mu.Unlock()
+	}
+}
+
+func TestEmailNotifierSend(t *testing.T) {
+	srv := newSMTPServer(t)
+	notifier, err := newNotifier(NotificationChannel{Name: "correo", Type: "email", Email: &EmailConfig{
+		Host:     "127.0.0.1",
+		Port:     srv.port(),
+		Username: "monitor",
+		Password: "secreto",
+		From:     "status@example.com",
+		To:       []string{"ops@example.com", "dev@example.com"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sent, err := notifier.Send(context.Background(), testEvent(EventDown))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	if srv.auth != "\x00monitor\x00secreto" {
+		t.Errorf("AUTH PLAIN = %q", srv.auth)
+	}
+	if srv.from != "status@example.com" || strings.Join(srv.rcpts, ",") != "ops@example.com,dev@example.com" {
+		t.Errorf("sobre: from %q, rcpt %v", srv.from, srv.rcpts)
+	}
+	if srv.data != sent {
+		t.Error("el mensaje recibido no coincide con el registrado")
+	}
+
+	msg, err := mail.ReadMessage(strings.NewReader(srv.data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil || subject != "[Caído] api" {
+		t.Errorf("Subject = %q (%v)", subject, err)
+	}
+	if msg.Header.Get("To") != "ops@example.com, dev@example.com" {
+		t.Errorf("To = %q", msg.Header.Get("To"))
+	}
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("Content-Type = %q (%v)", mediaType, err)
+	}
+	parts := multipart.NewReader(msg.Body, params["boundary"])
+	var types []string
+	for {
+		part, err := parts.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(part)
+		types = append(types, part.Header.Get("Content-Type"))
+		if !strings.Contains(string(body), "HTTP 503") {
+			t.Errorf("la parte %s no incluye el error:\n%s", part.Header.Get("Content-Type"), body)
+		}
+	}
+	if strings.Join(types, ",") != "text/plain; charset=utf-8,text/html; charset=utf-8" {
+		t.Errorf("partes = %v", types)
+	}
+}
+
+func TestEmailNotifierErrors(t *testing.T) {
+	srv := newSMTPServer(t)
+	tests := []struct {
+		name    string
+		to      []string
+		wantErr string
+	}{
+		{"sin destinatarios", nil, "no hay destinatarios"},
+		{"destinatario rechazado", []string{"ops@example.com", "rechazado@example.com"}, "destinatario rechazado@example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notifier, err := newNotifier(NotificationChannel{Name: "correo", Type: "email", Email: &EmailConfig{
+				Host: "127.0.0.1", Port: srv.port(), From: "status@example.com", To: tt.to,
+			}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = notifier.Send(context.Background(), testEvent(EventDown))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, se esperaba %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// El puerto se toma de la configuración; sin servidor el envío falla
+func TestEmailNotifierConnectionRefused(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	notifier, _ := newNotifier(NotificationChannel{Name: "correo", Type: "email", Email: &EmailConfig{
+		Host: "127.0.0.1", Port: port, From: "status@example.com", To: []string{"ops@example.com"},
+	}})
+	if _, err := notifier.Send(context.Background(), testEvent(EventDown)); err == nil {
+		t.Error("se esperaba un error de conexión")
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+)
+
+// Política de escalado para caídas no resueltas. Se aplica la primera
+// política que coincida con el sitio.
+type EscalationPolicy struct {
+	Name   string   `json:"name"`
+	Sites  []string `json:"sites,omitempty"`  // vacío = todos los sitios
+	Groups []string `json:"groups,omitempty"` // vacío = todos los grupos
+
+	// Recordatorios cada RepeatMinutes mientras el sitio siga caído
+	RepeatMinutes  int      `json:"repeatMinutes,omitempty"`
+	RepeatChannels []string `json:"repeatChannels,omitempty"` // vacío = canales del sitio
+
+	// Escalado a otros canales tras EscalateAfterMinutes de caída
+	EscalateAfterMinutes int      `json:"escalateAfterMinutes,omitempty"`
+	EscalateChannels     []string `json:"escalateChannels,omitempty"`
+}
+
+func (p EscalationPolicy) appliesTo(site Site) bool {
+	return NotificationChannel{Sites: p.Sites, Groups: p.Groups}.appliesTo(site)
+}
+
+// escalationPolicy devuelve la política aplicable al sitio, si existe
+func (s *StatusPageService) escalationPolicy(site Site) (EscalationPolicy, bool) {
+	for _, policy := range s.currentConfig().Escalations {
+		if policy.appliesTo(site) {
+			return policy, true
+		}
+	}
+	return EscalationPolicy{}, false
+}
+
+// processEscalation envía recordatorios y escalados del incidente abierto del
+// sitio. No hace nada si el incidente fue reconocido. Un sitio que pasa a
+// "unreachable" sigue con el incidente abierto, así que también se escala.
+func (s *StatusPageService) processEscalation(site Site, check StatusCheck) {
+	if check.Status == "up" {
+		return
+	}
+	policy, ok := s.escalationPolicy(site)
+	if !ok {
+		return
+	}
+
+	s.transitionMu.Lock()
+	defer s.transitionMu.Unlock()
+
+	incident, err := s.openIncident(site.Name)
+	if err != nil {
+		log.Printf("Error obteniendo incidente abierto para %s: %v", site.Name, err)
+		return
+	}
+	if incident == nil || incident.AcknowledgedAt != nil {
+		return
+	}
+
+	now := time.Now().UTC()
+	event := NotificationEvent{
+		Site:       site,
+		Check:      check,
+		Incident:   *incident,
+		OccurredAt: now,
+	}
+
+	if policy.EscalateAfterMinutes > 0 && incident.EscalatedAt == nil &&
+		now.Sub(incident.StartedAt) >= time.Duration(policy.EscalateAfterMinutes)*time.Minute {
+		if _, err := s.db.Exec(`UPDATE incidents SET escalated_at = ? WHERE id = ?`, now, incident.ID); err != nil {
+			log.Printf("Error guardando escalado del incidente %d: %v", incident.ID, err)
+			return
+		}
+		event.Type = EventEscalation
+		log.Printf("Escalado (%s): %s", policy.Name, event.Summary())
+		s.notifyChannels(policy.EscalateChannels, event)
+	}
+
+	if policy.RepeatMinutes > 0 {
+		last := incident.StartedAt
+		if incident.LastReminderAt != nil {
+			last = *incident.LastReminderAt
+		}
+		if now.Sub(last) < time.Duration(policy.RepeatMinutes)*time.Minute {
+			return
+		}
+		if _, err := s.db.Exec(`UPDATE incidents SET last_reminder_at = ? WHERE id = ?`, now, incident.ID); err != nil {
+			log.Printf("Error guardando recordatorio del incidente %d: %v", incident.ID, err)
+			return
+		}
+		event.Type = EventReminder
+		log.Printf("Recordatorio (%s): %s", policy.Name, event.Summary())
+		if len(policy.RepeatChannels) > 0 {
+			s.notifyChannels(policy.RepeatChannels, event)
+		} else {
+			s.notify(event)
+		}
+	}
+}
+
+// notifyEscalatedRecovery avisa de la recuperación a los canales a los que se
+// escaló el incidente
+func (s *StatusPageService) notifyEscalatedRecovery(event NotificationEvent) {
+	if event.Incident.EscalatedAt == nil {
+		return
+	}
+	policy, ok := s.escalationPolicy(event.Site)
+	if !ok {
+		return
+	}
+
+	var channels []string
+	for _, name := range policy.EscalateChannels {
+		// Los canales generales del sitio ya recibieron la recuperación
+		if channel, ok := s.findNotificationChannel(name); ok && !channel.RoutedOnly && channel.appliesTo(event.Site) {
+			continue
+		}
+		channels = append(channels, name)
+	}
+	s.notifyChannels(channels, event)
+}
+
+// AcknowledgeIncident marca el incidente abierto como reconocido, lo que
+// detiene los recordatorios y el escalado
+func (s *StatusPageService) AcknowledgeIncident(id int64) error {
+	s.transitionMu.Lock()
+	defer s.transitionMu.Unlock()
+
+	row := s.db.QueryRow(`SELECT `+incidentColumns+` FROM incidents WHERE id = ?`, id)
+	incident, err := scanIncident(row)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("incidente %d no encontrado", id)
+	}
+	if err != nil {
+		return err
+	}
+	if !incident.IsOpen() {
+		return fmt.Errorf("el incidente %d ya está resuelto", id)
+	}
+	if incident.AcknowledgedAt != nil {
+		return nil
+	}
+
+	_, err = s.db.Exec(`UPDATE incidents SET acknowledged_at = ? WHERE id = ?`, time.Now().UTC(), id)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Incidente %d de %s reconocido", id, incident.SiteName)
+	return nil
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEscalationAndAcknowledge(t *testing.T) {
+	s := newTestService(t)
+	general, oncall := newWebhookRecorder(t), newWebhookRecorder(t)
+	site := Site{Name: "app", URL: "https://app.example.com", Method: "GET", Timeout: 5, DependsOn: []string{"vpn"}}
+	err := s.updateConfig(func(c *Config) error {
+		c.Sites = []Site{{Name: "vpn", URL: "https://vpn.example.com", Method: "GET", Timeout: 5}, site}
+		c.Notifications = []NotificationChannel{
+			{Name: "general", Type: "webhook", Enabled: true, RoutedOnly: true, Webhook: &WebhookConfig{URL: general.URL}},
+			{Name: "oncall", Type: "webhook", Enabled: true, RoutedOnly: true, Webhook: &WebhookConfig{URL: oncall.URL}},
+		}
+		c.Escalations = []EscalationPolicy{{Name: "guardia", RepeatMinutes: 5, RepeatChannels: []string{"general"},
+			EscalateAfterMinutes: 10, EscalateChannels: []string{"oncall"}}}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	insertIncident(t, s, "app", 20*time.Minute)
+	incident, err := s.openIncident("app")
+	if err != nil || incident == nil {
+		t.Fatalf("incidente = %+v, %v", incident, err)
+	}
+
+	process := func(status string) (reminders, escalations []webhookPayload) {
+		s.processEscalation(site, StatusCheck{SiteName: "app", Status: status})
+		s.inFlight.Wait()
+		return general.take(), oncall.take()
+	}
+
+	// Un check "up" no escala aunque el incidente siga abierto
+	if reminders, escalations := process("up"); len(reminders)+len(escalations) != 0 {
+		t.Errorf("con el sitio operativo no debe notificarse: %+v %+v", reminders, escalations)
+	}
+
+	// Si el padre cae, el sitio pasa a "unreachable" pero el incidente sigue abierto
+	reminders, escalations := process("unreachable")
+	if len(escalations) != 1 || escalations[0].Event != EventEscalation {
+		t.Errorf("escalados = %+v", escalations)
+	}
+	if len(reminders) != 1 || reminders[0].Event != EventReminder {
+		t.Errorf("recordatorios = %+v", reminders)
+	}
+	if reminders, escalations := process("down"); len(reminders)+len(escalations) != 0 {
+		t.Errorf("antes de RepeatMinutes no debe repetirse: %+v %+v", reminders, escalations)
+	}
+
+	if err := s.AcknowledgeIncident(incident.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.db.Exec(`UPDATE incidents SET last_reminder_at = ? WHERE id = ?`, time.Now().UTC().Add(-time.Hour), incident.ID); err != nil {
+		t.Fatal(err)
+	}
+	if reminders, escalations := process("down"); len(reminders)+len(escalations) != 0 {
+		t.Errorf("un incidente reconocido no debe notificarse: %+v %+v", reminders, escalations)
+	}
+	if err := s.AcknowledgeIncident(incident.ID); err != nil {
+		t.Errorf("reconocer dos veces no es un error: %v", err)
+	}
+}
+
+func TestAcknowledgeIncidentErrors(t *testing.T) {
+	s := newTestService(t)
+	insertIncident(t, s, "api", time.Minute)
+	if _, err := s.db.Exec(`UPDATE incidents SET resolved_at = ?`, time.Now().UTC()); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.AcknowledgeIncident(1); err == nil || !strings.Contains(err.Error(), "ya está resuelto") {
+		t.Errorf("incidente resuelto: %v", err)
+	}
+	if err := s.AcknowledgeIncident(99); err == nil || !strings.Contains(err.Error(), "no encontrado") {
+		t.Errorf("incidente inexistente: %v", err)
+	}
+
+	// Un error de la base de datos no se presenta como "no encontrado"
+	if _, err := s.db.Exec(`DROP TABLE incidents`); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AcknowledgeIncident(1); err == nil || strings.Contains(err.Error(), "no encontrado") {
+		t.Errorf("error de la base de datos: %v", err)
+	}
+}
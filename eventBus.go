@@ -0,0 +1,184 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Tipos de eventos del bus interno
+const (
+	BusEventCheck      = "check"      // check guardado
+	BusEventTransition = "transition" // caída, recuperación u oscilación
+)
+
+// Evento publicado en el bus interno (UI de escritorio y /api/v1/events)
+type BusEvent struct {
+	Type       string           `json:"type"`
+	Site       string           `json:"site"`
+	Group      string           `json:"group,omitempty"`
+	Check      *StatusCheck     `json:"check,omitempty"`
+	Transition *TransitionEvent `json:"transition,omitempty"`
+	Time       time.Time        `json:"time"`
+}
+
+// Resumen de una transición de estado
+type TransitionEvent struct {
+	Event      string `json:"event"` // tipo de NotificationEvent ("down", "recovery", "flapping", ...)
+	Summary    string `json:"summary"`
+	IncidentID int64  `json:"incidentId,omitempty"`
+	Flapping   bool   `json:"flapping,omitempty"`
+}
+
+// Buffer de cada suscriptor; si se llena se descartan eventos para no bloquear los checks
+const eventBusBuffer = 64
+
+// eventBus reparte los eventos entre los suscriptores sin bloquear a quien publica
+type eventBus struct {
+	mu          sync.Mutex
+	nextID      int
+	subscribers map[int]chan BusEvent
+}
+
+// subscribe registra un suscriptor; la función devuelta lo elimina y cierra el canal
+func (b *eventBus) subscribe() (<-chan BusEvent, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.subscribers == nil {
+		b.subscribers = make(map[int]chan BusEvent)
+	}
+	id := b.nextID
+	b.nextID++
+	ch := make(chan BusEvent, eventBusBuffer)
+	b.subscribers[id] = ch
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			delete(b.subscribers, id)
+			close(ch)
+		})
+	}
+}
+
+func (b *eventBus) publish(event BusEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// publishCheck publica un check recién guardado
+func (s *StatusPageService) publishCheck(site Site, check StatusCheck) {
+	s.events.publish(BusEvent{
+		Type:  BusEventCheck,
+		Site:  site.Name,
+		Group: site.Group,
+		Check: &check,
+		Time:  check.CheckedAt,
+	})
+}
+
+// publishTransition publica una caída, recuperación u oscilación
+func (s *StatusPageService) publishTransition(event NotificationEvent) {
+	s.events.publish(BusEvent{
+		Type:  BusEventTransition,
+		Site:  event.Site.Name,
+		Group: event.Site.Group,
+		Transition: &TransitionEvent{
+			Event:      event.Type,
+			Summary:    event.Summary(),
+			IncidentID: event.Incident.ID,
+			Flapping:   event.Flapping,
+		},
+		Time: event.OccurredAt,
+	})
+}
+
+// Intervalo de los comentarios de keep-alive del stream SSE
+const sseHeartbeat = 15 * time.Second
+
+// apiEvents transmite el bus como Server-Sent Events. Filtros opcionales:
+// ?site= (repetible o separado por comas), ?group= y ?type=check|transition
+func (s *StatusPageService) apiEvents(w http.ResponseWriter, r *http.Request) {
+	if !allowMethods(w, r, http.MethodGet) {
+		return
+	}
+
+	query := r.URL.Query()
+	sites := make(map[string]bool)
+	for _, value := range query["site"] {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				sites[name] = true
+			}
+		}
+	}
+	group := query.Get("group")
+	eventType := query.Get("type")
+	if eventType != "" && eventType != BusEventCheck && eventType != BusEventTransition {
+		writeAPIError(w, http.StatusBadRequest, fmt.Sprintf("tipo de evento desconocido '%s'", eventType))
+		return
+	}
+
+	events, unsubscribe := s.events.subscribe()
+	defer unsubscribe()
+
+	rc := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, "retry: 5000\n\n")
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(sseHeartbeat)
+	defer heartbeat.Stop()
+
+	var done <-chan struct{}
+	if s.ctx != nil {
+		done = s.ctx.Done()
+	}
+
+	id := 0
+	for {
+		select {
+		case event := <-events:
+			if (len(sites) > 0 && !sites[event.Site]) || (group != "" && event.Group != group) ||
+				(eventType != "" && event.Type != eventType) {
+				continue
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				log.Printf("Error codificando evento: %v", err)
+				continue
+			}
+			id++
+			fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", id, event.Type, data)
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": ping\n\n")
+		case <-r.Context().Done():
+			return
+		case <-done:
+			// Apagado del servicio: cerrar el stream para no retrasar el cierre del servidor
+			return
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,313 @@
+package main
+
+import (
+	"bufio"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Formatos de exportación del historial de checks
+const (
+	ExportCSV    = "csv"
+	ExportJSON   = "json"
+	ExportNDJSON = "ndjson" // un objeto JSON por línea
+)
+
+var exportContentTypes = map[string]string{
+	ExportCSV:    "text/csv; charset=utf-8",
+	ExportJSON:   "application/json; charset=utf-8",
+	ExportNDJSON: "application/x-ndjson",
+}
+
+var exportCSVHeader = []string{"id", "site_name", "site_url", "status", "status_code",
+	"response_time_ms", "checked_at", "error_message", "cert_expires_at"}
+
+// parseExportTime acepta RFC 3339 o una fecha "2006-01-02" (hora local);
+// una cadena vacía significa sin límite
+func parseExportTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("fecha inválida '%s' (use 2006-01-02 o RFC 3339)", value)
+}
+
+// parseExportRange interpreta from y to; una fecha sin hora en to incluye el día completo
+func parseExportRange(fromValue, toValue string) (from, to time.Time, err error) {
+	if from, err = parseExportTime(fromValue); err != nil {
+		return
+	}
+	if to, err = parseExportTime(toValue); err != nil {
+		return
+	}
+	if len(strings.TrimSpace(toValue)) == len("2006-01-02") {
+		to = to.AddDate(0, 0, 1)
+	}
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		err = fmt.Errorf("el inicio debe ser anterior al fin")
+	}
+	return
+}
+
+// checkExportSites rechaza nombres de sitio que no están configurados, para que
+// un nombre mal escrito no produzca una exportación vacía sin aviso
+func (s *StatusPageService) checkExportSites(siteNames []string) error {
+	for _, name := range siteNames {
+		if _, ok := s.findSite(name); !ok {
+			return fmt.Errorf("sitio '%s' no encontrado", name)
+		}
+	}
+	return nil
+}
+
+// writeHistory escribe los checks de los sitios indicados (todos si está vacío)
+// con checked_at en [from, to) en orden cronológico. Las filas se leen y escriben
+// de una en una, sin cargar el historial en memoria. Devuelve las filas escritas.
+func (s *StatusPageService) writeHistory(w io.Writer, siteNames []string, from, to time.Time, format string) (int, error) {
+	if _, ok := exportContentTypes[format]; !ok {
+		return 0, fmt.Errorf("formato de exportación desconocido '%s' (csv, json o ndjson)", format)
+	}
+
+	var conditions []string
+	var args []any
+	if len(siteNames) > 0 {
+		placeholders := make([]string, len(siteNames))
+		for i, name := range siteNames {
+			placeholders[i] = "?"
+			args = append(args, name)
+		}
+		conditions = append(conditions, "site_name IN ("+strings.Join(placeholders, ", ")+")")
+	}
+	// checked_at se guarda como texto UTC (CURRENT_TIMESTAMP)
+	if !from.IsZero() {
+		conditions = append(conditions, "checked_at >= ?")
+		args = append(args, from.UTC().Format("2006-01-02 15:04:05"))
+	}
+	if !to.IsZero() {
+		conditions = append(conditions, "checked_at < ?")
+		args = append(args, to.UTC().Format("2006-01-02 15:04:05"))
+	}
+
+	query := `
+	SELECT id, site_name, site_url, status, status_code, response_time, checked_at,
+		   COALESCE(error_message, ''), cert_expires_at
+	FROM status_checks`
+	if len(conditions) > 0 {
+		query += "\n\tWHERE " + strings.Join(conditions, " AND ")
+	}
+	query += "\n\tORDER BY checked_at, id"
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	buf := bufio.NewWriter(w)
+	csvWriter := csv.NewWriter(buf)
+	encoder := json.NewEncoder(buf)
+
+	switch format {
+	case ExportCSV:
+		csvWriter.Write(exportCSVHeader)
+	case ExportJSON:
+		buf.WriteString("[\n")
+	}
+
+	count := 0
+	for rows.Next() {
+		var check StatusCheck
+		var certExpiresAt sql.NullTime
+		if err := rows.Scan(&check.ID, &check.SiteName, &check.SiteURL, &check.Status,
+			&check.StatusCode, &check.ResponseTime, &check.CheckedAt, &check.ErrorMessage, &certExpiresAt); err != nil {
+			return count, err
+		}
+		check.CertExpiresAt = nullTimePtr(certExpiresAt)
+
+		switch format {
+		case ExportCSV:
+			certExpires := ""
+			if check.CertExpiresAt != nil {
+				certExpires = check.CertExpiresAt.UTC().Format(time.RFC3339)
+			}
+			err = csvWriter.Write([]string{strconv.Itoa(check.ID), check.SiteName, check.SiteURL, check.Status,
+				strconv.Itoa(check.StatusCode), strconv.FormatInt(check.ResponseTime, 10),
+				check.CheckedAt.UTC().Format(time.RFC3339), check.ErrorMessage, certExpires})
+		case ExportJSON:
+			if count > 0 {
+				buf.WriteString(",\n")
+			}
+			var data []byte
+			if data, err = json.Marshal(check); err == nil {
+				_, err = buf.Write(data)
+			}
+		case ExportNDJSON:
+			err = encoder.Encode(check)
+		}
+		if err != nil {
+			return count, err
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return count, err
+	}
+
+	switch format {
+	case ExportCSV:
+		csvWriter.Flush()
+		if err := csvWriter.Error(); err != nil {
+			return count, err
+		}
+	case ExportJSON:
+		if count > 0 {
+			buf.WriteString("\n")
+		}
+		buf.WriteString("]\n")
+	}
+	return count, buf.Flush()
+}
+
+// exportFilename propone un nombre de archivo para la exportación
+func exportFilename(format string) string {
+	return fmt.Sprintf("status-history-%s.%s", time.Now().Format("20060102-150405"), format)
+}
+
+// ExportHistory exporta el historial de checks de los sitios indicados (todos si
+// está vacío) entre from y to ("2006-01-02" o RFC 3339, vacíos sin límite) al
+// archivo elegido en un diálogo de guardado. Devuelve la ruta, o "" si se canceló.
+func (s *StatusPageService) ExportHistory(siteNames []string, from, to, format string) (string, error) {
+	format = strings.ToLower(format)
+	if _, ok := exportContentTypes[format]; !ok {
+		return "", fmt.Errorf("formato de exportación desconocido '%s' (csv, json o ndjson)", format)
+	}
+	fromTime, toTime, err := parseExportRange(from, to)
+	if err != nil {
+		return "", err
+	}
+	if err := s.checkExportSites(siteNames); err != nil {
+		return "", err
+	}
+
+	path, err := promptSaveFile(exportFilename(format), strings.ToUpper(format), "*."+format)
+	if err != nil || path == "" {
+		return "", err
+	}
+
+	count, err := s.exportHistoryToFile(path, siteNames, fromTime, toTime, format)
+	if err != nil {
+		return "", err
+	}
+	log.Printf("Historial exportado a %s (%d checks)", path, count)
+	return path, nil
+}
+
+// exportHistoryToFile escribe la exportación en path; si falla se elimina el archivo parcial
+func (s *StatusPageService) exportHistoryToFile(path string, siteNames []string, from, to time.Time, format string) (int, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+	count, err := s.writeHistory(file, siteNames, from, to, format)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return 0, err
+	}
+	return count, nil
+}
+
+// apiExport sirve GET /api/v1/export?format=csv|json|ndjson&site=...&from=...&to=...
+// (site repetible o separado por comas)
+func (s *StatusPageService) apiExport(w http.ResponseWriter, r *http.Request) {
+	if !allowMethods(w, r, http.MethodGet) {
+		return
+	}
+
+	query := r.URL.Query()
+	format := strings.ToLower(query.Get("format"))
+	if format == "" {
+		format = ExportCSV
+	}
+	contentType, ok := exportContentTypes[format]
+	if !ok {
+		writeAPIError(w, http.StatusBadRequest, fmt.Sprintf("formato de exportación desconocido '%s' (csv, json o ndjson)", format))
+		return
+	}
+	from, to, err := parseExportRange(query.Get("from"), query.Get("to"))
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var siteNames []string
+	for _, value := range query["site"] {
+		siteNames = append(siteNames, splitList(value)...)
+	}
+	if err := s.checkExportSites(siteNames); err != nil {
+		writeAPIError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, exportFilename(format)))
+	if count, err := s.writeHistory(w, siteNames, from, to, format); err != nil {
+		// Las cabeceras ya se enviaron; el cliente recibe un archivo truncado
+		log.Printf("Error exportando historial tras %d checks: %v", count, err)
+	}
+}
+
+// cliExportHistory implementa "export history"
+func cliExportHistory(args []string) int {
+	fs := newCLIFlagSet("export history")
+	sites := fs.String("site", "", "sitios separados por comas (por defecto todos)")
+	from := fs.String("from", "", "desde (2006-01-02 o RFC 3339)")
+	to := fs.String("to", "", "hasta, incluido si es una fecha (2006-01-02 o RFC 3339)")
+	format := fs.String("format", ExportCSV, "formato: csv, json o ndjson")
+	output := fs.String("o", "", "archivo de salida (por defecto la salida estándar)")
+	if _, err := parseCLIFlags(fs, args); err != nil {
+		return exitUsage
+	}
+	if _, ok := exportContentTypes[*format]; !ok {
+		return cliUsageError("formato de exportación desconocido '%s' (csv, json o ndjson)", *format)
+	}
+	fromTime, toTime, err := parseExportRange(*from, *to)
+	if err != nil {
+		return cliUsageError("%v", err)
+	}
+
+	s := NewStatusPageService()
+	defer closeCLIService(s)
+
+	if err := s.checkExportSites(splitList(*sites)); err != nil {
+		return cliError(err)
+	}
+	if *output == "" {
+		_, err = s.writeHistory(os.Stdout, splitList(*sites), fromTime, toTime, *format)
+	} else {
+		var count int
+		if count, err = s.exportHistoryToFile(*output, splitList(*sites), fromTime, toTime, *format); err == nil {
+			fmt.Fprintf(os.Stderr, "%d checks exportados a %s\n", count, *output)
+		}
+	}
+	if err != nil {
+		return cliError(err)
+	}
+	return exitOK
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// exportService crea un servicio con dos sitios y un check de cada uno el 1 y
+// el 2 de mayo de 2024 (UTC)
+func exportService(t *testing.T) *StatusPageService {
+	t.Helper()
+	s := newTestService(t)
+	s.config.Sites = []Site{
+		{Name: "api", URL: "https://api.example.com", Method: "GET", Timeout: 5},
+		{Name: "web", URL: "https://example.com", Method: "GET", Timeout: 5},
+	}
+	for _, checkedAt := range []string{"2024-05-01 10:00:00", "2024-05-02 10:00:00"} {
+		for _, site := range s.config.Sites {
+			_, err := s.db.Exec(`INSERT INTO status_checks (site_name, site_url, status, status_code, response_time, error_message, checked_at)
+				VALUES (?, ?, 'down', 503, 120, ?, ?)`, site.Name, site.URL, `HTTP 503: "Service Unavailable", reintente`, checkedAt)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	return s
+}
+
+func TestWriteHistoryCSV(t *testing.T) {
+	s := exportService(t)
+	var buf bytes.Buffer
+	count, err := s.writeHistory(&buf, []string{"api"}, time.Time{}, time.Time{}, ExportCSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 || len(records) != 3 {
+		t.Fatalf("filas = %d, registros = %q", count, records)
+	}
+	if strings.Join(records[0], ",") != strings.Join(exportCSVHeader, ",") {
+		t.Errorf("cabecera = %q", records[0])
+	}
+	for _, record := range records[1:] {
+		if record[1] != "api" || record[7] != `HTTP 503: "Service Unavailable", reintente` ||
+			(record[6] != "2024-05-01T10:00:00Z" && record[6] != "2024-05-02T10:00:00Z") {
+			t.Errorf("registro = %q", record)
+		}
+	}
+}
+
+func TestWriteHistoryJSON(t *testing.T) {
+	s := exportService(t)
+
+	var buf bytes.Buffer
+	count, err := s.writeHistory(&buf, nil, time.Time{}, time.Time{}, ExportJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var checks []StatusCheck
+	if err := json.Unmarshal(buf.Bytes(), &checks); err != nil {
+		t.Fatal(err)
+	}
+	if count != 4 || len(checks) != 4 || !checks[0].CheckedAt.Before(checks[3].CheckedAt) {
+		t.Errorf("checks = %+v", checks)
+	}
+
+	// Sin filas se escribe un array vacío, no null
+	buf.Reset()
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	if count, err = s.writeHistory(&buf, nil, from, time.Time{}, ExportJSON); err != nil {
+		t.Fatal(err)
+	}
+	checks = nil
+	if err := json.Unmarshal(buf.Bytes(), &checks); err != nil || count != 0 || checks == nil || len(checks) != 0 {
+		t.Errorf("sin filas: %d, %q, %v", count, buf.String(), err)
+	}
+}
+
+func TestWriteHistoryNDJSON(t *testing.T) {
+	s := exportService(t)
+	var buf bytes.Buffer
+	count, err := s.writeHistory(&buf, []string{"web"}, time.Time{}, time.Time{}, ExportNDJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if count != 2 || len(lines) != 2 {
+		t.Fatalf("filas = %d, líneas = %q", count, lines)
+	}
+	for _, line := range lines {
+		var check StatusCheck
+		if err := json.Unmarshal([]byte(line), &check); err != nil || check.SiteName != "web" {
+			t.Errorf("línea %q: %+v, %v", line, check, err)
+		}
+	}
+}
+
+// Una fecha sin hora en to incluye todo ese día, y nada del siguiente
+func TestParseExportRange(t *testing.T) {
+	from, to, err := parseExportRange("2024-05-01", "2024-05-01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !from.Equal(time.Date(2024, 5, 1, 0, 0, 0, 0, time.Local)) || !to.Equal(time.Date(2024, 5, 2, 0, 0, 0, 0, time.Local)) {
+		t.Errorf("rango = [%v, %v)", from, to)
+	}
+
+	if _, to, err = parseExportRange("", "2024-05-01T12:00:00Z"); err != nil || !to.Equal(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("to RFC 3339 = %v, %v", to, err)
+	}
+	for _, tt := range [][2]string{{"2024-05-02", "2024-05-01"}, {"ayer", ""}, {"", "2024-13-01"}} {
+		if _, _, err := parseExportRange(tt[0], tt[1]); err == nil {
+			t.Errorf("%q: se esperaba un error", tt)
+		}
+	}
+
+	s := exportService(t)
+	from, to, _ = parseExportRange("2024-05-01T00:00:00Z", "2024-05-02T00:00:00Z")
+	count, err := s.writeHistory(&bytes.Buffer{}, nil, from, to, ExportCSV)
+	if err != nil || count != 2 {
+		t.Errorf("checks del 1 de mayo = %d, %v", count, err)
+	}
+}
+
+func TestAPIExport(t *testing.T) {
+	s := exportService(t)
+
+	rec := httptest.NewRecorder()
+	s.apiExport(rec, httptest.NewRequest(http.MethodGet, "/api/v1/export?format=ndjson&site=api,web", nil))
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != exportContentTypes[ExportNDJSON] ||
+		strings.Count(rec.Body.String(), "\n") != 4 {
+		t.Errorf("respuesta = %d %q", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	s.apiExport(rec, httptest.NewRequest(http.MethodGet, "/api/v1/export?site=api&site=no-existe", nil))
+	if rec.Code != http.StatusNotFound || !strings.Contains(rec.Body.String(), "no-existe") {
+		t.Errorf("sitio desconocido: %d %q", rec.Code, rec.Body.String())
+	}
+
+	if _, err := s.ExportHistory([]string{"no-existe"}, "", "", ExportCSV); err == nil || !strings.Contains(err.Error(), "no encontrado") {
+		t.Errorf("ExportHistory con un sitio desconocido: %v", err)
+	}
+}
@@ -0,0 +1,315 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Entradas máximas por feed
+const maxFeedEntries = 50
+
+// Entrada de un feed: cada actualización de un incidente o mantenimiento
+type feedEntry struct {
+	ID      string
+	Title   string
+	Content string
+	Updated time.Time
+}
+
+// feedEntries reúne incidentes automáticos, incidentes manuales y mantenimientos
+// de los sitios públicos; si group no está vacío solo los de ese grupo
+func (s *StatusPageService) feedEntries(group string) ([]feedEntry, error) {
+	config := s.currentConfig()
+	var scope []Site
+	inScope := make(map[string]bool)
+	for _, site := range config.Sites {
+		if config.StatusPage.isPublic(site) && (group == "" || site.Group == group) {
+			scope = append(scope, site)
+			inScope[site.Name] = true
+		}
+	}
+	affectsScope := func(affects func(Site) bool) bool {
+		for _, site := range scope {
+			if affects(site) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var entries []feedEntry
+
+	incidents, err := s.GetIncidents("", 200)
+	if err != nil {
+		return nil, err
+	}
+	for _, incident := range incidents {
+		if !inScope[incident.SiteName] {
+			continue
+		}
+		id := fmt.Sprintf("incident-%d", incident.ID)
+		entries = append(entries, feedEntry{
+			ID:      id + "-down",
+			Title:   incident.SiteName + ": caída detectada",
+			Content: publicErrorMessage(StatusCheck{Status: "down", ErrorMessage: incident.ErrorMessage}),
+			Updated: incident.StartedAt,
+		})
+		if incident.AcknowledgedAt != nil {
+			entries = append(entries, feedEntry{
+				ID:      id + "-acknowledged",
+				Title:   incident.SiteName + ": en investigación",
+				Content: "El equipo está trabajando en la caída.",
+				Updated: *incident.AcknowledgedAt,
+			})
+		}
+		if incident.ResolvedAt != nil {
+			entries = append(entries, feedEntry{
+				ID:      id + "-resolved",
+				Title:   incident.SiteName + ": recuperado",
+				Content: "Duración de la caída: " + incident.Duration().String(),
+				Updated: *incident.ResolvedAt,
+			})
+		}
+	}
+
+	manual, err := s.GetManualIncidents(100)
+	if err != nil {
+		return nil, err
+	}
+	for _, incident := range manual {
+		if !affectsScope(incident.Affects) {
+			continue
+		}
+		for _, update := range incident.Updates {
+			entries = append(entries, feedEntry{
+				ID:      fmt.Sprintf("manual-incident-%d-update-%d", incident.ID, update.ID),
+				Title:   incident.Title + ": " + incidentStatusLabels[update.Status],
+				Content: update.Message,
+				Updated: update.CreatedAt,
+			})
+		}
+	}
+
+	maintenances, err := s.GetMaintenances(true)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for _, m := range maintenances {
+		if !affectsScope(m.Affects) {
+			continue
+		}
+		id := fmt.Sprintf("maintenance-%d", m.ID)
+		period := m.StartsAt.Local().Format("2006-01-02 15:04") + " – " + m.EndsAt.Local().Format("2006-01-02 15:04 MST")
+		content := strings.TrimSpace(period + "\n" + m.Description)
+		entries = append(entries, feedEntry{
+			ID:      id + "-scheduled",
+			Title:   "Mantenimiento programado: " + m.Title,
+			Content: content,
+			Updated: m.CreatedAt,
+		})
+		if !now.Before(m.StartsAt) {
+			entries = append(entries, feedEntry{
+				ID:      id + "-started",
+				Title:   "Mantenimiento en curso: " + m.Title,
+				Content: content,
+				Updated: m.StartsAt,
+			})
+		}
+		if !now.Before(m.EndsAt) {
+			entries = append(entries, feedEntry{
+				ID:      id + "-completed",
+				Title:   "Mantenimiento completado: " + m.Title,
+				Content: content,
+				Updated: m.EndsAt,
+			})
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Updated.After(entries[j].Updated)
+	})
+	if len(entries) > maxFeedEntries {
+		entries = entries[:maxFeedEntries]
+	}
+	return entries, nil
+}
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	Description string  `xml:"description"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Author  atomAuthor  `xml:"author"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	Title   string   `xml:"title"`
+	ID      string   `xml:"id"`
+	Updated string   `xml:"updated"`
+	Link    atomLink `xml:"link"`
+	Content atomText `xml:"content"`
+}
+
+type atomText struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// publicBaseURL devuelve la URL pública configurada o la deduce de la petición
+func (s *StatusPageService) publicBaseURL(r *http.Request) string {
+	config := s.currentConfig()
+	if config.StatusPage.PublicURL != "" {
+		return strings.TrimRight(config.StatusPage.PublicURL, "/")
+	}
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
+
+// handleFeed sirve /feeds/{format} y /feeds/groups/{group}/{format} con format "rss.xml" o "atom.xml"
+func (s *StatusPageService) handleFeed(w http.ResponseWriter, r *http.Request) {
+	config := s.currentConfig()
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "método no permitido", http.StatusMethodNotAllowed)
+		return
+	}
+
+	group := r.PathValue("group")
+	title := config.StatusPage.title()
+	if group != "" {
+		found := false
+		for _, g := range config.Groups {
+			found = found || g.Name == group
+		}
+		if !found {
+			http.NotFound(w, r)
+			return
+		}
+		title += " – " + group
+	}
+
+	format := r.PathValue("format")
+	if format != "rss.xml" && format != "atom.xml" {
+		http.NotFound(w, r)
+		return
+	}
+
+	entries, err := s.feedEntries(group)
+	if err != nil {
+		log.Printf("Error generando el feed: %v", err)
+		http.Error(w, "error generando el feed", http.StatusInternalServerError)
+		return
+	}
+
+	base := s.publicBaseURL(r)
+	pageURL := base + "/status"
+	updated := time.Now()
+	if len(entries) > 0 {
+		updated = entries[0].Updated
+	}
+
+	var feed any
+	contentType := "application/rss+xml; charset=utf-8"
+	if format == "rss.xml" {
+		channel := rssChannel{
+			Title:         title,
+			Link:          pageURL,
+			Description:   "Incidentes y mantenimientos de " + title,
+			LastBuildDate: updated.UTC().Format(time.RFC1123Z),
+		}
+		for _, entry := range entries {
+			channel.Items = append(channel.Items, rssItem{
+				Title:       entry.Title,
+				Link:        pageURL,
+				Description: entry.Content,
+				GUID:        rssGUID{Value: pageURL + "#" + entry.ID},
+				PubDate:     entry.Updated.UTC().Format(time.RFC1123Z),
+			})
+		}
+		feed = rssFeed{Version: "2.0", Channel: channel}
+	} else {
+		contentType = "application/atom+xml; charset=utf-8"
+		atom := atomFeed{
+			Title:   title,
+			ID:      base + r.URL.Path,
+			Updated: updated.UTC().Format(time.RFC3339),
+			Links: []atomLink{
+				{Href: base + r.URL.Path, Rel: "self"},
+				{Href: pageURL, Rel: "alternate"},
+			},
+			Author: atomAuthor{Name: config.StatusPage.title()},
+		}
+		for _, entry := range entries {
+			atom.Entries = append(atom.Entries, atomEntry{
+				Title:   entry.Title,
+				ID:      pageURL + "#" + entry.ID,
+				Updated: entry.Updated.UTC().Format(time.RFC3339),
+				Link:    atomLink{Href: pageURL, Rel: "alternate"},
+				Content: atomText{Type: "text", Value: entry.Content},
+			})
+		}
+		feed = atom
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	encoder := xml.NewEncoder(&buf)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(feed); err != nil {
+		log.Printf("Error generando el feed: %v", err)
+		http.Error(w, "error generando el feed", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.Write(buf.Bytes())
+}
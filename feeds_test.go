@@ -0,0 +1,67 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// Los feeds solo incluyen sitios públicos y no exponen el error original
+func TestFeedEntriesPublicAndSanitized(t *testing.T) {
+	s := newTestService(t)
+	s.config.Sites = []Site{
+		{Name: "api", URL: "http://10.0.0.12:8080", Method: "GET", Timeout: 5, Group: "Payments"},
+		{Name: "admin", URL: "http://10.0.0.13", Method: "GET", Timeout: 5, Group: "Internal"},
+	}
+	s.config.StatusPage.PublicSites = []string{"api"}
+
+	for _, site := range s.config.Sites {
+		s.saveStatusCheck(site, StatusCheck{SiteName: site.Name, SiteURL: site.URL, Status: "down",
+			ErrorMessage: "dial tcp: lookup db.internal.corp: no such host"})
+	}
+
+	entries, err := s.feedEntries("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("entradas = %+v, se esperaba solo la caída de api", entries)
+	}
+	if entries[0].Title != "api: caída detectada" || entries[0].Content != publicErrorMessages["dns"] {
+		t.Errorf("entrada = %+v", entries[0])
+	}
+	if strings.Contains(entries[0].Content, "internal.corp") {
+		t.Error("el feed expone el error original")
+	}
+}
+
+func TestManualIncidentUpdates(t *testing.T) {
+	s := newTestService(t)
+	s.config.Sites = []Site{{Name: "api", URL: "https://api.example.com", Method: "GET", Timeout: 5}}
+
+	incident, err := s.CreateManualIncident("Lentitud en pagos", IncidentInvestigating, "Investigando", []string{"api"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(incident.Updates) != 1 || incident.Updates[0].Message != "Investigando" {
+		t.Fatalf("actualizaciones = %+v", incident.Updates)
+	}
+
+	if incident, err = s.AddIncidentUpdate(incident.ID, IncidentResolved, "Solucionado"); err != nil {
+		t.Fatal(err)
+	}
+	if incident.Status != IncidentResolved || incident.ResolvedAt == nil || len(incident.Updates) != 2 {
+		t.Errorf("incidente = %+v", incident)
+	}
+
+	// Un incidente inexistente no deja actualizaciones huérfanas
+	if _, err := s.AddIncidentUpdate(incident.ID+1, IncidentMonitoring, "Vigilando"); err == nil {
+		t.Error("se esperaba un error para un incidente inexistente")
+	}
+	var updates int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM incident_updates`).Scan(&updates); err != nil {
+		t.Fatal(err)
+	}
+	if updates != 2 {
+		t.Errorf("actualizaciones guardadas = %d, se esperaban 2", updates)
+	}
+}
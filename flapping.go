@@ -0,0 +1,234 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+)
+
+// Configuración de la detección de oscilación (flapping)
+type FlappingConfig struct {
+	Disabled     bool `json:"disabled,omitempty"`
+	WindowChecks int  `json:"windowChecks,omitempty"` // checks analizados (por defecto 10)
+	MinChanges   int  `json:"minChanges,omitempty"`   // cambios de estado para considerar oscilación (por defecto 4)
+}
+
+const (
+	defaultFlappingWindowChecks = 10
+	defaultFlappingMinChanges   = 4
+)
+
+func (c FlappingConfig) windowChecks() int {
+	if c.WindowChecks <= 1 {
+		return defaultFlappingWindowChecks
+	}
+	return c.WindowChecks
+}
+
+func (c FlappingConfig) minChanges() int {
+	if c.MinChanges <= 0 {
+		return defaultFlappingMinChanges
+	}
+	return c.MinChanges
+}
+
+func (s *StatusPageService) initFlappingDB() error {
+	createTableSQL := `
+	CREATE TABLE IF NOT EXISTS flapping_states (
+		site_name TEXT PRIMARY KEY,
+		since DATETIME NOT NULL
+	);
+	`
+
+	_, err := s.db.Exec(createTableSQL)
+	return err
+}
+
+// stateChanges cuenta los cambios entre "up" y "down" en los últimos checks del sitio
+func (s *StatusPageService) stateChanges(siteName string, window int) (int, error) {
+	rows, err := s.db.Query(`
+	SELECT status FROM status_checks
+	WHERE site_name = ? AND status IN ('up', 'down')
+	ORDER BY checked_at DESC, id DESC
+	LIMIT ?`, siteName, window)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	changes := 0
+	previous := ""
+	for rows.Next() {
+		var status string
+		if err := rows.Scan(&status); err != nil {
+			return 0, err
+		}
+		if previous != "" && status != previous {
+			changes++
+		}
+		previous = status
+	}
+	return changes, rows.Err()
+}
+
+// isFlapping indica si el sitio está marcado como oscilante
+func (s *StatusPageService) isFlapping(siteName string) bool {
+	var since time.Time
+	err := s.db.QueryRow(`SELECT since FROM flapping_states WHERE site_name = ?`, siteName).Scan(&since)
+	if err != nil && err != sql.ErrNoRows {
+		log.Printf("Error obteniendo oscilación de %s: %v", siteName, err)
+	}
+	return err == nil
+}
+
+// Resultado de evaluateFlapping
+const (
+	flappingUnchanged = iota
+	flappingStarted
+	flappingEnded
+)
+
+// evaluateFlapping actualiza el estado de oscilación del sitio tras cada check
+// y envía una única notificación al empezar y otra al terminar
+func (s *StatusPageService) evaluateFlapping(site Site, check StatusCheck) int {
+	config := s.currentConfig()
+	if config.Flapping.Disabled || (check.Status != "up" && check.Status != "down") {
+		return flappingUnchanged
+	}
+
+	window, threshold := config.Flapping.windowChecks(), config.Flapping.minChanges()
+	changes, err := s.stateChanges(site.Name, window)
+	if err != nil {
+		log.Printf("Error calculando oscilación de %s: %v", site.Name, err)
+		return flappingUnchanged
+	}
+
+	s.transitionMu.Lock()
+	defer s.transitionMu.Unlock()
+
+	wasFlapping := s.isFlapping(site.Name)
+	flapping := changes >= threshold
+	// Histéresis: deja de oscilar solo cuando los cambios bajan a la mitad del umbral
+	if wasFlapping && changes > threshold/2 {
+		flapping = true
+	}
+	if flapping == wasFlapping {
+		return flappingUnchanged
+	}
+
+	now := time.Now().UTC()
+	event := NotificationEvent{
+		Site:       site,
+		Check:      check,
+		Flapping:   flapping,
+		OccurredAt: now,
+		Alert: &AlertInfo{
+			Rule:    "Oscilación",
+			Type:    "flapping",
+			Message: fmt.Sprintf("%d cambios de estado en los últimos %d checks", changes, window),
+			Value:   float64(changes),
+		},
+	}
+	if incident, err := s.openIncident(site.Name); err == nil && incident != nil {
+		event.Incident = *incident
+	}
+
+	if flapping {
+		_, err = s.db.Exec(`INSERT OR REPLACE INTO flapping_states (site_name, since) VALUES (?, ?)`, site.Name, now)
+		event.Type = EventFlapping
+	} else {
+		_, err = s.db.Exec(`DELETE FROM flapping_states WHERE site_name = ?`, site.Name)
+		event.Type = EventFlappingEnd
+	}
+	if err != nil {
+		log.Printf("Error guardando oscilación de %s: %v", site.Name, err)
+		return flappingUnchanged
+	}
+
+	log.Printf("Oscilación: %s", event.Summary())
+	s.notify(event)
+	s.emitTransition(event)
+	if flapping {
+		return flappingStarted
+	}
+	return flappingEnded
+}
+
+// lastNotifiedTransition devuelve el último "down" o "recovery" entregado al
+// canal para el sitio (tipo vacío si no hay ninguno)
+func (s *StatusPageService) lastNotifiedTransition(channel, siteName string) (string, int64, error) {
+	var eventType string
+	var incidentID int64
+	err := s.db.QueryRow(`
+	SELECT event_type, COALESCE(incident_id, 0) FROM notification_log
+	WHERE channel = ? AND site_name = ? AND status = 'sent' AND event_type IN (?, ?)
+	ORDER BY id DESC
+	LIMIT 1`, channel, siteName, EventDown, EventRecovery).Scan(&eventType, &incidentID)
+	if err == sql.ErrNoRows {
+		return "", 0, nil
+	}
+	return eventType, incidentID, err
+}
+
+// reconcileNotifications se ejecuta al empezar o terminar una oscilación y
+// envía a cada canal la caída o recuperación que le falte. Mientras el sitio
+// oscila no debe quedar ninguna caída abierta en los canales (la alerta de
+// oscilación la sustituye); al terminar, solo la del incidente abierto, si lo hay.
+func (s *StatusPageService) reconcileNotifications(site Site, check StatusCheck) {
+	s.transitionMu.Lock()
+	defer s.transitionMu.Unlock()
+
+	flapping := s.isFlapping(site.Name)
+	var open *Incident
+	if !flapping {
+		var err error
+		if open, err = s.openIncident(site.Name); err != nil {
+			log.Printf("Error obteniendo incidente abierto para %s: %v", site.Name, err)
+			return
+		}
+	}
+
+	now := time.Now().UTC()
+	recovered := make(map[int64]NotificationEvent)
+	for _, channel := range s.currentConfig().Notifications {
+		if !channel.Enabled || channel.RoutedOnly || !channel.appliesTo(site) {
+			continue
+		}
+
+		lastType, lastIncident, err := s.lastNotifiedTransition(channel.Name, site.Name)
+		if err != nil {
+			log.Printf("Error obteniendo notificaciones de '%s' para %s: %v", channel.Name, site.Name, err)
+			continue
+		}
+
+		// Caída notificada que ya no corresponde: se cierra (en PagerDuty, "resolve" de la misma clave)
+		if lastType == EventDown && (open == nil || open.ID != lastIncident) {
+			event, ok := recovered[lastIncident]
+			if !ok {
+				incident, err := s.incidentByID(lastIncident)
+				if err != nil {
+					log.Printf("Error obteniendo incidente %d: %v", lastIncident, err)
+					continue
+				}
+				event = NotificationEvent{Type: EventRecovery, Site: site, Check: check, Incident: *incident,
+					Flapping: flapping, OccurredAt: now}
+				recovered[lastIncident] = event
+			}
+			s.dispatch(channel, event)
+		}
+
+		// Caída actual que el canal no recibió mientras el sitio oscilaba
+		if open != nil && !(lastType == EventDown && lastIncident == open.ID) {
+			s.dispatch(channel, NotificationEvent{Type: EventDown, Site: site, Check: check, Incident: *open, OccurredAt: now})
+		}
+	}
+
+	for _, event := range recovered {
+		log.Printf("Conciliación tras oscilación: %s", event.Summary())
+		s.notifyEscalatedRecovery(event)
+	}
+	if open != nil {
+		log.Printf("Conciliación tras oscilación: %s sigue caído (incidente %d)", site.Name, open.ID)
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestFlappingReconcilesNotifications(t *testing.T) {
+	s := newTestService(t)
+	target := newToggleServer(t)
+	hook := newWebhookRecorder(t)
+
+	site := Site{Name: "api", URL: target.URL, Method: "GET", Timeout: 2}
+	s.config.Sites = []Site{site}
+	s.config.Notifications = []NotificationChannel{{Name: "hook", Type: "webhook", Enabled: true,
+		Webhook: &WebhookConfig{URL: hook.URL}}}
+
+	// check ejecuta un check con el estado indicado y devuelve los eventos
+	// recibidos por el webhook como "tipo:incidente", ordenados
+	check := func(up bool) []string {
+		target.set(up)
+		s.checkSite(site)
+		s.inFlight.Wait()
+		var events []string
+		for _, p := range hook.take() {
+			events = append(events, fmt.Sprintf("%s:%d", p.Event, p.IncidentID))
+		}
+		sort.Strings(events)
+		return events
+	}
+	expect := func(step string, got []string, want ...string) {
+		t.Helper()
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("%s: eventos %v, se esperaba %v", step, got, want)
+		}
+	}
+
+	expect("inicial", check(true))
+	expect("caída 1", check(false), "down:1")
+	expect("recuperación 1", check(true), "recovery:1")
+	expect("caída 2", check(false), "down:2")
+	// Cuarto cambio: empieza la oscilación y se cierra la caída ya notificada
+	expect("inicio de oscilación", check(true), "flapping:2", "recovery:2")
+
+	// Se mantiene caído sin notificar cada cambio hasta que deja de oscilar;
+	// entonces se envía la caída del incidente abierto
+	var ended []string
+	for i := 0; i < 15 && ended == nil; i++ {
+		events := check(false)
+		if len(events) > 0 {
+			ended = events
+		}
+	}
+	expect("fin de oscilación", ended, "down:3", "flapping_end:3")
+	expect("recuperación 3", check(true), "recovery:3")
+}
@@ -10,110 +10,2108 @@ import {Create as $Create} from "@wailsio/runtime";
 // @ts-ignore: Unused imports
 import * as time$0 from "../time/models.js";
 
-export class Config {
+/**
+ * Token de la API; el valor en claro solo se devuelve al crearlo
+ */
+export class APIToken {
     /**
-     * Creates a new Config instance.
-     * @param {Partial<Config>} [$$source = {}] - The source object to create the Config.
+     * Creates a new APIToken instance.
+     * @param {Partial<APIToken>} [$$source = {}] - The source object to create the APIToken.
      */
     constructor($$source = {}) {
-        if (!("checkInterval" in $$source)) {
+        if (!("id" in $$source)) {
             /**
-             * intervalo en segundos
              * @member
              * @type {number}
              */
-            this["checkInterval"] = 0;
+            this["id"] = 0;
         }
-        if (!("retentionDays" in $$source)) {
+        if (!("name" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["name"] = "";
+        }
+        if (!("prefix" in $$source)) {
+            /**
+             * primeros caracteres para identificarlo
+             * @member
+             * @type {string}
+             */
+            this["prefix"] = "";
+        }
+        if (!("scope" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["scope"] = "";
+        }
+        if (!("createdAt" in $$source)) {
+            /**
+             * @member
+             * @type {time$0.Time}
+             */
+            this["createdAt"] = null;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {time$0.Time | null | undefined}
+             */
+            this["lastUsedAt"] = null;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {time$0.Time | null | undefined}
+             */
+            this["revokedAt"] = null;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new APIToken instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {APIToken}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new APIToken(/** @type {Partial<APIToken>} */($$parsedSource));
+    }
+}
+
+/**
+ * Información de la alerta incluida en el NotificationEvent
+ */
+export class AlertInfo {
+    /**
+     * Creates a new AlertInfo instance.
+     * @param {Partial<AlertInfo>} [$$source = {}] - The source object to create the AlertInfo.
+     */
+    constructor($$source = {}) {
+        if (!("rule" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["rule"] = "";
+        }
+        if (!("type" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["type"] = "";
+        }
+        if (!("message" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["message"] = "";
+        }
+        if (!("value" in $$source)) {
             /**
-             * días de retención de datos
              * @member
              * @type {number}
              */
-            this["retentionDays"] = 0;
+            this["value"] = 0;
         }
-        if (!("sites" in $$source)) {
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new AlertInfo instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {AlertInfo}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new AlertInfo(/** @type {Partial<AlertInfo>} */($$parsedSource));
+    }
+}
+
+/**
+ * Regla de alerta configurable en config.json
+ */
+export class AlertRule {
+    /**
+     * Creates a new AlertRule instance.
+     * @param {Partial<AlertRule>} [$$source = {}] - The source object to create the AlertRule.
+     */
+    constructor($$source = {}) {
+        if (!("name" in $$source)) {
             /**
              * @member
-             * @type {Site[]}
+             * @type {string}
+             */
+            this["name"] = "";
+        }
+        if (!("type" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["type"] = "";
+        }
+        if (!("enabled" in $$source)) {
+            /**
+             * @member
+             * @type {boolean}
+             */
+            this["enabled"] = false;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * vacío = todos los sitios
+             * @member
+             * @type {string[] | undefined}
              */
             this["sites"] = [];
         }
+        if (/** @type {any} */(false)) {
+            /**
+             * vacío = todos los grupos
+             * @member
+             * @type {string[] | undefined}
+             */
+            this["groups"] = [];
+        }
+        if (!("channels" in $$source)) {
+            /**
+             * canales de notificación por nombre
+             * @member
+             * @type {string[]}
+             */
+            this["channels"] = [];
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {number | undefined}
+             */
+            this["minutes"] = 0;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {number | undefined}
+             */
+            this["failures"] = 0;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {number | undefined}
+             */
+            this["checks"] = 0;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {number | undefined}
+             */
+            this["thresholdMs"] = 0;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {number | undefined}
+             */
+            this["windowMinutes"] = 0;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {number | undefined}
+             */
+            this["days"] = 0;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {number | undefined}
+             */
+            this["sloPercent"] = 0;
+        }
 
         Object.assign(this, $$source);
     }
 
     /**
-     * Creates a new Config instance from a string or object.
+     * Creates a new AlertRule instance from a string or object.
      * @param {any} [$$source = {}]
-     * @returns {Config}
+     * @returns {AlertRule}
      */
     static createFrom($$source = {}) {
-        const $$createField2_0 = $$createType1;
+        const $$createField3_0 = $$createType0;
+        const $$createField4_0 = $$createType0;
+        const $$createField5_0 = $$createType0;
         let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
         if ("sites" in $$parsedSource) {
-            $$parsedSource["sites"] = $$createField2_0($$parsedSource["sites"]);
+            $$parsedSource["sites"] = $$createField3_0($$parsedSource["sites"]);
         }
-        return new Config(/** @type {Partial<Config>} */($$parsedSource));
+        if ("groups" in $$parsedSource) {
+            $$parsedSource["groups"] = $$createField4_0($$parsedSource["groups"]);
+        }
+        if ("channels" in $$parsedSource) {
+            $$parsedSource["channels"] = $$createField5_0($$parsedSource["channels"]);
+        }
+        return new AlertRule(/** @type {Partial<AlertRule>} */($$parsedSource));
     }
 }
 
 /**
- * Estructura para estadísticas diarias
+ * Estado persistido de una regla para un sitio
  */
-export class DailyStats {
+export class AlertState {
     /**
-     * Creates a new DailyStats instance.
-     * @param {Partial<DailyStats>} [$$source = {}] - The source object to create the DailyStats.
+     * Creates a new AlertState instance.
+     * @param {Partial<AlertState>} [$$source = {}] - The source object to create the AlertState.
      */
     constructor($$source = {}) {
-        if (!("date" in $$source)) {
+        if (!("rule" in $$source)) {
             /**
              * @member
              * @type {string}
              */
-            this["date"] = "";
+            this["rule"] = "";
         }
-        if (!("totalChecks" in $$source)) {
+        if (!("siteName" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["siteName"] = "";
+        }
+        if (!("state" in $$source)) {
+            /**
+             * "firing", "resolved"
+             * @member
+             * @type {string}
+             */
+            this["state"] = "";
+        }
+        if (!("value" in $$source)) {
             /**
              * @member
              * @type {number}
              */
-            this["totalChecks"] = 0;
+            this["value"] = 0;
         }
-        if (!("upChecks" in $$source)) {
+        if (!("message" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["message"] = "";
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {time$0.Time | null | undefined}
+             */
+            this["firedAt"] = null;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {time$0.Time | null | undefined}
+             */
+            this["resolvedAt"] = null;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new AlertState instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {AlertState}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new AlertState(/** @type {Partial<AlertState>} */($$parsedSource));
+    }
+}
+
+/**
+ * Configuración de los canales de chat (Slack, Discord, Microsoft Teams)
+ */
+export class ChatConfig {
+    /**
+     * Creates a new ChatConfig instance.
+     * @param {Partial<ChatConfig>} [$$source = {}] - The source object to create the ChatConfig.
+     */
+    constructor($$source = {}) {
+        if (!("webhookUrl" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["webhookUrl"] = "";
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * Enlace incluido en el mensaje; por defecto la URL del sitio
+             * @member
+             * @type {string | undefined}
+             */
+            this["linkUrl"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ChatConfig instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {ChatConfig}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new ChatConfig(/** @type {Partial<ChatConfig>} */($$parsedSource));
+    }
+}
+
+export class Config {
+    /**
+     * Creates a new Config instance.
+     * @param {Partial<Config>} [$$source = {}] - The source object to create the Config.
+     */
+    constructor($$source = {}) {
+        if (!("checkInterval" in $$source)) {
+            /**
+             * intervalo en segundos
+             * @member
+             * @type {number}
+             */
+            this["checkInterval"] = 0;
+        }
+        if (!("retentionDays" in $$source)) {
+            /**
+             * días de retención de datos
+             * @member
+             * @type {number}
+             */
+            this["retentionDays"] = 0;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * orden de los grupos en el dashboard
+             * @member
+             * @type {Group[] | undefined}
+             */
+            this["groups"] = [];
+        }
+        if (!("sites" in $$source)) {
+            /**
+             * @member
+             * @type {Site[]}
+             */
+            this["sites"] = [];
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {NotificationChannel[] | undefined}
+             */
+            this["notifications"] = [];
+        }
+        if (!("desktop" in $$source)) {
+            /**
+             * @member
+             * @type {DesktopNotificationConfig}
+             */
+            this["desktop"] = (new DesktopNotificationConfig());
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {AlertRule[] | undefined}
+             */
+            this["alertRules"] = [];
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {EscalationPolicy[] | undefined}
+             */
+            this["escalations"] = [];
+        }
+        if (!("flapping" in $$source)) {
+            /**
+             * @member
+             * @type {FlappingConfig}
+             */
+            this["flapping"] = (new FlappingConfig());
+        }
+        if (!("http" in $$source)) {
+            /**
+             * @member
+             * @type {HTTPServerConfig}
+             */
+            this["http"] = (new HTTPServerConfig());
+        }
+        if (!("statusPage" in $$source)) {
+            /**
+             * @member
+             * @type {StatusPageConfig}
+             */
+            this["statusPage"] = (new StatusPageConfig());
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new Config instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {Config}
+     */
+    static createFrom($$source = {}) {
+        const $$createField2_0 = $$createType2;
+        const $$createField3_0 = $$createType4;
+        const $$createField4_0 = $$createType6;
+        const $$createField5_0 = $$createType7;
+        const $$createField6_0 = $$createType9;
+        const $$createField7_0 = $$createType11;
+        const $$createField8_0 = $$createType12;
+        const $$createField9_0 = $$createType13;
+        const $$createField10_0 = $$createType14;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("groups" in $$parsedSource) {
+            $$parsedSource["groups"] = $$createField2_0($$parsedSource["groups"]);
+        }
+        if ("sites" in $$parsedSource) {
+            $$parsedSource["sites"] = $$createField3_0($$parsedSource["sites"]);
+        }
+        if ("notifications" in $$parsedSource) {
+            $$parsedSource["notifications"] = $$createField4_0($$parsedSource["notifications"]);
+        }
+        if ("desktop" in $$parsedSource) {
+            $$parsedSource["desktop"] = $$createField5_0($$parsedSource["desktop"]);
+        }
+        if ("alertRules" in $$parsedSource) {
+            $$parsedSource["alertRules"] = $$createField6_0($$parsedSource["alertRules"]);
+        }
+        if ("escalations" in $$parsedSource) {
+            $$parsedSource["escalations"] = $$createField7_0($$parsedSource["escalations"]);
+        }
+        if ("flapping" in $$parsedSource) {
+            $$parsedSource["flapping"] = $$createField8_0($$parsedSource["flapping"]);
+        }
+        if ("http" in $$parsedSource) {
+            $$parsedSource["http"] = $$createField9_0($$parsedSource["http"]);
+        }
+        if ("statusPage" in $$parsedSource) {
+            $$parsedSource["statusPage"] = $$createField10_0($$parsedSource["statusPage"]);
+        }
+        return new Config(/** @type {Partial<Config>} */($$parsedSource));
+    }
+}
+
+/**
+ * Estructura para estadísticas diarias
+ */
+export class DailyStats {
+    /**
+     * Creates a new DailyStats instance.
+     * @param {Partial<DailyStats>} [$$source = {}] - The source object to create the DailyStats.
+     */
+    constructor($$source = {}) {
+        if (!("date" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["date"] = "";
+        }
+        if (!("totalChecks" in $$source)) {
+            /**
+             * no incluye checks "unreachable"
+             * @member
+             * @type {number}
+             */
+            this["totalChecks"] = 0;
+        }
+        if (!("upChecks" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["upChecks"] = 0;
+        }
+        if (!("downChecks" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["downChecks"] = 0;
+        }
+        if (!("unreachableChecks" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["unreachableChecks"] = 0;
+        }
+        if (!("uptimePercent" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["uptimePercent"] = 0;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new DailyStats instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {DailyStats}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new DailyStats(/** @type {Partial<DailyStats>} */($$parsedSource));
+    }
+}
+
+/**
+ * Configuración de las notificaciones de escritorio
+ */
+export class DesktopNotificationConfig {
+    /**
+     * Creates a new DesktopNotificationConfig instance.
+     * @param {Partial<DesktopNotificationConfig>} [$$source = {}] - The source object to create the DesktopNotificationConfig.
+     */
+    constructor($$source = {}) {
+        if (!("doNotDisturb" in $$source)) {
+            /**
+             * silencia todas las notificaciones de escritorio
+             * @member
+             * @type {boolean}
+             */
+            this["doNotDisturb"] = false;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new DesktopNotificationConfig instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {DesktopNotificationConfig}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new DesktopNotificationConfig(/** @type {Partial<DesktopNotificationConfig>} */($$parsedSource));
+    }
+}
+
+/**
+ * Configuración de un canal de correo SMTP
+ */
+export class EmailConfig {
+    /**
+     * Creates a new EmailConfig instance.
+     * @param {Partial<EmailConfig>} [$$source = {}] - The source object to create the EmailConfig.
+     */
+    constructor($$source = {}) {
+        if (!("host" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["host"] = "";
+        }
+        if (!("port" in $$source)) {
+            /**
+             * 25, 587 (STARTTLS) o 465 (TLS)
+             * @member
+             * @type {number}
+             */
+            this["port"] = 0;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * "none", "starttls", "tls"
+             * @member
+             * @type {string | undefined}
+             */
+            this["security"] = "";
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {string | undefined}
+             */
+            this["username"] = "";
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {string | undefined}
+             */
+            this["password"] = "";
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * no verificar el certificado del servidor
+             * @member
+             * @type {boolean | undefined}
+             */
+            this["skipVerify"] = false;
+        }
+        if (!("from" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["from"] = "";
+        }
+        if (!("to" in $$source)) {
+            /**
+             * @member
+             * @type {string[]}
+             */
+            this["to"] = [];
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new EmailConfig instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {EmailConfig}
+     */
+    static createFrom($$source = {}) {
+        const $$createField7_0 = $$createType0;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("to" in $$parsedSource) {
+            $$parsedSource["to"] = $$createField7_0($$parsedSource["to"]);
+        }
+        return new EmailConfig(/** @type {Partial<EmailConfig>} */($$parsedSource));
+    }
+}
+
+/**
+ * Política de escalado para caídas no resueltas. Se aplica la primera
+ * política que coincida con el sitio.
+ */
+export class EscalationPolicy {
+    /**
+     * Creates a new EscalationPolicy instance.
+     * @param {Partial<EscalationPolicy>} [$$source = {}] - The source object to create the EscalationPolicy.
+     */
+    constructor($$source = {}) {
+        if (!("name" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["name"] = "";
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * vacío = todos los sitios
+             * @member
+             * @type {string[] | undefined}
+             */
+            this["sites"] = [];
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * vacío = todos los grupos
+             * @member
+             * @type {string[] | undefined}
+             */
+            this["groups"] = [];
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * Recordatorios cada RepeatMinutes mientras el sitio siga caído
+             * @member
+             * @type {number | undefined}
+             */
+            this["repeatMinutes"] = 0;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * vacío = canales del sitio
+             * @member
+             * @type {string[] | undefined}
+             */
+            this["repeatChannels"] = [];
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * Escalado a otros canales tras EscalateAfterMinutes de caída
+             * @member
+             * @type {number | undefined}
+             */
+            this["escalateAfterMinutes"] = 0;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {string[] | undefined}
+             */
+            this["escalateChannels"] = [];
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new EscalationPolicy instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {EscalationPolicy}
+     */
+    static createFrom($$source = {}) {
+        const $$createField1_0 = $$createType0;
+        const $$createField2_0 = $$createType0;
+        const $$createField4_0 = $$createType0;
+        const $$createField6_0 = $$createType0;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("sites" in $$parsedSource) {
+            $$parsedSource["sites"] = $$createField1_0($$parsedSource["sites"]);
+        }
+        if ("groups" in $$parsedSource) {
+            $$parsedSource["groups"] = $$createField2_0($$parsedSource["groups"]);
+        }
+        if ("repeatChannels" in $$parsedSource) {
+            $$parsedSource["repeatChannels"] = $$createField4_0($$parsedSource["repeatChannels"]);
+        }
+        if ("escalateChannels" in $$parsedSource) {
+            $$parsedSource["escalateChannels"] = $$createField6_0($$parsedSource["escalateChannels"]);
+        }
+        return new EscalationPolicy(/** @type {Partial<EscalationPolicy>} */($$parsedSource));
+    }
+}
+
+/**
+ * Configuración de la detección de oscilación (flapping)
+ */
+export class FlappingConfig {
+    /**
+     * Creates a new FlappingConfig instance.
+     * @param {Partial<FlappingConfig>} [$$source = {}] - The source object to create the FlappingConfig.
+     */
+    constructor($$source = {}) {
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {boolean | undefined}
+             */
+            this["disabled"] = false;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * checks analizados (por defecto 10)
+             * @member
+             * @type {number | undefined}
+             */
+            this["windowChecks"] = 0;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * cambios de estado para considerar oscilación (por defecto 4)
+             * @member
+             * @type {number | undefined}
+             */
+            this["minChanges"] = 0;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new FlappingConfig instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {FlappingConfig}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new FlappingConfig(/** @type {Partial<FlappingConfig>} */($$parsedSource));
+    }
+}
+
+/**
+ * Configuración de Gotify (siempre autoalojado)
+ */
+export class GotifyConfig {
+    /**
+     * Creates a new GotifyConfig instance.
+     * @param {Partial<GotifyConfig>} [$$source = {}] - The source object to create the GotifyConfig.
+     */
+    constructor($$source = {}) {
+        if (!("baseUrl" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["baseUrl"] = "";
+        }
+        if (!("token" in $$source)) {
+            /**
+             * token de la aplicación
+             * @member
+             * @type {string}
+             */
+            this["token"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new GotifyConfig instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {GotifyConfig}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new GotifyConfig(/** @type {Partial<GotifyConfig>} */($$parsedSource));
+    }
+}
+
+/**
+ * Grupo de sitios (ej. "Payments", "Internal")
+ */
+export class Group {
+    /**
+     * Creates a new Group instance.
+     * @param {Partial<Group>} [$$source = {}] - The source object to create the Group.
+     */
+    constructor($$source = {}) {
+        if (!("name" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["name"] = "";
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {string | undefined}
+             */
+            this["description"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new Group instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {Group}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new Group(/** @type {Partial<Group>} */($$parsedSource));
+    }
+}
+
+/**
+ * Estado agregado de un grupo de sitios
+ */
+export class GroupStatusDetail {
+    /**
+     * Creates a new GroupStatusDetail instance.
+     * @param {Partial<GroupStatusDetail>} [$$source = {}] - The source object to create the GroupStatusDetail.
+     */
+    constructor($$source = {}) {
+        if (!("name" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["name"] = "";
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {string | undefined}
+             */
+            this["description"] = "";
+        }
+        if (!("status" in $$source)) {
+            /**
+             * "up", "degraded", "down", "unknown"
+             * @member
+             * @type {string}
+             */
+            this["status"] = "";
+        }
+        if (!("totalSites" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["totalSites"] = 0;
+        }
+        if (!("upSites" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["upSites"] = 0;
+        }
+        if (!("downSites" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["downSites"] = 0;
+        }
+        if (!("totalChecks" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["totalChecks"] = 0;
+        }
+        if (!("upChecks" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["upChecks"] = 0;
+        }
+        if (!("uptimePercent" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["uptimePercent"] = 0;
+        }
+        if (!("sites" in $$source)) {
+            /**
+             * @member
+             * @type {string[]}
+             */
+            this["sites"] = [];
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new GroupStatusDetail instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {GroupStatusDetail}
+     */
+    static createFrom($$source = {}) {
+        const $$createField9_0 = $$createType0;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("sites" in $$parsedSource) {
+            $$parsedSource["sites"] = $$createField9_0($$parsedSource["sites"]);
+        }
+        return new GroupStatusDetail(/** @type {Partial<GroupStatusDetail>} */($$parsedSource));
+    }
+}
+
+/**
+ * Configuración del servidor HTTP embebido (API REST)
+ */
+export class HTTPServerConfig {
+    /**
+     * Creates a new HTTPServerConfig instance.
+     * @param {Partial<HTTPServerConfig>} [$$source = {}] - The source object to create the HTTPServerConfig.
+     */
+    constructor($$source = {}) {
+        if (!("enabled" in $$source)) {
+            /**
+             * @member
+             * @type {boolean}
+             */
+            this["enabled"] = false;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * por defecto 127.0.0.1:8420
+             * @member
+             * @type {string | undefined}
+             */
+            this["address"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new HTTPServerConfig instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {HTTPServerConfig}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new HTTPServerConfig(/** @type {Partial<HTTPServerConfig>} */($$parsedSource));
+    }
+}
+
+/**
+ * Incidente: periodo en que un sitio estuvo caído
+ */
+export class Incident {
+    /**
+     * Creates a new Incident instance.
+     * @param {Partial<Incident>} [$$source = {}] - The source object to create the Incident.
+     */
+    constructor($$source = {}) {
+        if (!("id" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["id"] = 0;
+        }
+        if (!("siteName" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["siteName"] = "";
+        }
+        if (!("startedAt" in $$source)) {
+            /**
+             * @member
+             * @type {time$0.Time}
+             */
+            this["startedAt"] = null;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {time$0.Time | null | undefined}
+             */
+            this["resolvedAt"] = null;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {string | undefined}
+             */
+            this["errorMessage"] = "";
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {time$0.Time | null | undefined}
+             */
+            this["acknowledgedAt"] = null;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {time$0.Time | null | undefined}
+             */
+            this["escalatedAt"] = null;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {time$0.Time | null | undefined}
+             */
+            this["lastReminderAt"] = null;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new Incident instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {Incident}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new Incident(/** @type {Partial<Incident>} */($$parsedSource));
+    }
+}
+
+/**
+ * Actualización publicada de un incidente manual
+ */
+export class IncidentUpdate {
+    /**
+     * Creates a new IncidentUpdate instance.
+     * @param {Partial<IncidentUpdate>} [$$source = {}] - The source object to create the IncidentUpdate.
+     */
+    constructor($$source = {}) {
+        if (!("id" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["id"] = 0;
+        }
+        if (!("status" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["status"] = "";
+        }
+        if (!("message" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["message"] = "";
+        }
+        if (!("createdAt" in $$source)) {
+            /**
+             * @member
+             * @type {time$0.Time}
+             */
+            this["createdAt"] = null;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new IncidentUpdate instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {IncidentUpdate}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new IncidentUpdate(/** @type {Partial<IncidentUpdate>} */($$parsedSource));
+    }
+}
+
+/**
+ * Ventana de mantenimiento programado. Si Sites y Groups están vacíos afecta a todos los sitios.
+ */
+export class Maintenance {
+    /**
+     * Creates a new Maintenance instance.
+     * @param {Partial<Maintenance>} [$$source = {}] - The source object to create the Maintenance.
+     */
+    constructor($$source = {}) {
+        if (!("id" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["id"] = 0;
+        }
+        if (!("title" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["title"] = "";
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {string | undefined}
+             */
+            this["description"] = "";
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {string[] | undefined}
+             */
+            this["sites"] = [];
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {string[] | undefined}
+             */
+            this["groups"] = [];
+        }
+        if (!("startsAt" in $$source)) {
+            /**
+             * @member
+             * @type {time$0.Time}
+             */
+            this["startsAt"] = null;
+        }
+        if (!("endsAt" in $$source)) {
+            /**
+             * @member
+             * @type {time$0.Time}
+             */
+            this["endsAt"] = null;
+        }
+        if (!("createdAt" in $$source)) {
+            /**
+             * @member
+             * @type {time$0.Time}
+             */
+            this["createdAt"] = null;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new Maintenance instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {Maintenance}
+     */
+    static createFrom($$source = {}) {
+        const $$createField3_0 = $$createType0;
+        const $$createField4_0 = $$createType0;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("sites" in $$parsedSource) {
+            $$parsedSource["sites"] = $$createField3_0($$parsedSource["sites"]);
+        }
+        if ("groups" in $$parsedSource) {
+            $$parsedSource["groups"] = $$createField4_0($$parsedSource["groups"]);
+        }
+        return new Maintenance(/** @type {Partial<Maintenance>} */($$parsedSource));
+    }
+}
+
+/**
+ * Incidente publicado manualmente (ej. degradación que los checks no detectan).
+ * Si Sites y Groups están vacíos afecta a todos los sitios.
+ */
+export class ManualIncident {
+    /**
+     * Creates a new ManualIncident instance.
+     * @param {Partial<ManualIncident>} [$$source = {}] - The source object to create the ManualIncident.
+     */
+    constructor($$source = {}) {
+        if (!("id" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["id"] = 0;
+        }
+        if (!("title" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["title"] = "";
+        }
+        if (!("status" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["status"] = "";
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {string[] | undefined}
+             */
+            this["sites"] = [];
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {string[] | undefined}
+             */
+            this["groups"] = [];
+        }
+        if (!("createdAt" in $$source)) {
+            /**
+             * @member
+             * @type {time$0.Time}
+             */
+            this["createdAt"] = null;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {time$0.Time | null | undefined}
+             */
+            this["resolvedAt"] = null;
+        }
+        if (!("updates" in $$source)) {
+            /**
+             * @member
+             * @type {IncidentUpdate[]}
+             */
+            this["updates"] = [];
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ManualIncident instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {ManualIncident}
+     */
+    static createFrom($$source = {}) {
+        const $$createField3_0 = $$createType0;
+        const $$createField4_0 = $$createType0;
+        const $$createField7_0 = $$createType16;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("sites" in $$parsedSource) {
+            $$parsedSource["sites"] = $$createField3_0($$parsedSource["sites"]);
+        }
+        if ("groups" in $$parsedSource) {
+            $$parsedSource["groups"] = $$createField4_0($$parsedSource["groups"]);
+        }
+        if ("updates" in $$parsedSource) {
+            $$parsedSource["updates"] = $$createField7_0($$parsedSource["updates"]);
+        }
+        return new ManualIncident(/** @type {Partial<ManualIncident>} */($$parsedSource));
+    }
+}
+
+/**
+ * Token recién creado con su valor en claro
+ */
+export class NewAPIToken {
+    /**
+     * Creates a new NewAPIToken instance.
+     * @param {Partial<NewAPIToken>} [$$source = {}] - The source object to create the NewAPIToken.
+     */
+    constructor($$source = {}) {
+        if (!("id" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["id"] = 0;
+        }
+        if (!("name" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["name"] = "";
+        }
+        if (!("prefix" in $$source)) {
+            /**
+             * primeros caracteres para identificarlo
+             * @member
+             * @type {string}
+             */
+            this["prefix"] = "";
+        }
+        if (!("scope" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["scope"] = "";
+        }
+        if (!("createdAt" in $$source)) {
+            /**
+             * @member
+             * @type {time$0.Time}
+             */
+            this["createdAt"] = null;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {time$0.Time | null | undefined}
+             */
+            this["lastUsedAt"] = null;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {time$0.Time | null | undefined}
+             */
+            this["revokedAt"] = null;
+        }
+        if (!("token" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["token"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new NewAPIToken instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {NewAPIToken}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new NewAPIToken(/** @type {Partial<NewAPIToken>} */($$parsedSource));
+    }
+}
+
+/**
+ * Canal de notificación configurado en config.json
+ */
+export class NotificationChannel {
+    /**
+     * Creates a new NotificationChannel instance.
+     * @param {Partial<NotificationChannel>} [$$source = {}] - The source object to create the NotificationChannel.
+     */
+    constructor($$source = {}) {
+        if (!("name" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["name"] = "";
+        }
+        if (!("type" in $$source)) {
+            /**
+             * "webhook", "email", "slack", "discord", "teams", "telegram", "ntfy", "gotify", "pagerduty"
+             * @member
+             * @type {string}
+             */
+            this["type"] = "";
+        }
+        if (!("enabled" in $$source)) {
+            /**
+             * @member
+             * @type {boolean}
+             */
+            this["enabled"] = false;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * reintentos ante fallo (por defecto 3)
+             * @member
+             * @type {number | undefined}
+             */
+            this["retries"] = 0;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * vacío = todos los sitios
+             * @member
+             * @type {string[] | undefined}
+             */
+            this["sites"] = [];
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * vacío = todos los grupos
+             * @member
+             * @type {string[] | undefined}
+             */
+            this["groups"] = [];
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * Solo recibe eventos enrutados por reglas de alerta o escalado
+             * @member
+             * @type {boolean | undefined}
+             */
+            this["routedOnly"] = false;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * Horario de envío; nil = siempre
+             * @member
+             * @type {NotificationSchedule | null | undefined}
+             */
+            this["schedule"] = null;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * Plantillas del título y del mensaje; vacío = plantillas por defecto del tipo
+             * @member
+             * @type {NotificationTemplates | null | undefined}
+             */
+            this["templates"] = null;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {WebhookConfig | null | undefined}
+             */
+            this["webhook"] = null;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {EmailConfig | null | undefined}
+             */
+            this["email"] = null;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {ChatConfig | null | undefined}
+             */
+            this["slack"] = null;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {ChatConfig | null | undefined}
+             */
+            this["discord"] = null;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {ChatConfig | null | undefined}
+             */
+            this["teams"] = null;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {TelegramConfig | null | undefined}
+             */
+            this["telegram"] = null;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {NtfyConfig | null | undefined}
+             */
+            this["ntfy"] = null;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {GotifyConfig | null | undefined}
+             */
+            this["gotify"] = null;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {PagerDutyConfig | null | undefined}
+             */
+            this["pagerduty"] = null;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new NotificationChannel instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {NotificationChannel}
+     */
+    static createFrom($$source = {}) {
+        const $$createField4_0 = $$createType0;
+        const $$createField5_0 = $$createType0;
+        const $$createField7_0 = $$createType18;
+        const $$createField8_0 = $$createType20;
+        const $$createField9_0 = $$createType22;
+        const $$createField10_0 = $$createType24;
+        const $$createField11_0 = $$createType26;
+        const $$createField12_0 = $$createType26;
+        const $$createField13_0 = $$createType26;
+        const $$createField14_0 = $$createType28;
+        const $$createField15_0 = $$createType30;
+        const $$createField16_0 = $$createType32;
+        const $$createField17_0 = $$createType34;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("sites" in $$parsedSource) {
+            $$parsedSource["sites"] = $$createField4_0($$parsedSource["sites"]);
+        }
+        if ("groups" in $$parsedSource) {
+            $$parsedSource["groups"] = $$createField5_0($$parsedSource["groups"]);
+        }
+        if ("schedule" in $$parsedSource) {
+            $$parsedSource["schedule"] = $$createField7_0($$parsedSource["schedule"]);
+        }
+        if ("templates" in $$parsedSource) {
+            $$parsedSource["templates"] = $$createField8_0($$parsedSource["templates"]);
+        }
+        if ("webhook" in $$parsedSource) {
+            $$parsedSource["webhook"] = $$createField9_0($$parsedSource["webhook"]);
+        }
+        if ("email" in $$parsedSource) {
+            $$parsedSource["email"] = $$createField10_0($$parsedSource["email"]);
+        }
+        if ("slack" in $$parsedSource) {
+            $$parsedSource["slack"] = $$createField11_0($$parsedSource["slack"]);
+        }
+        if ("discord" in $$parsedSource) {
+            $$parsedSource["discord"] = $$createField12_0($$parsedSource["discord"]);
+        }
+        if ("teams" in $$parsedSource) {
+            $$parsedSource["teams"] = $$createField13_0($$parsedSource["teams"]);
+        }
+        if ("telegram" in $$parsedSource) {
+            $$parsedSource["telegram"] = $$createField14_0($$parsedSource["telegram"]);
+        }
+        if ("ntfy" in $$parsedSource) {
+            $$parsedSource["ntfy"] = $$createField15_0($$parsedSource["ntfy"]);
+        }
+        if ("gotify" in $$parsedSource) {
+            $$parsedSource["gotify"] = $$createField16_0($$parsedSource["gotify"]);
+        }
+        if ("pagerduty" in $$parsedSource) {
+            $$parsedSource["pagerduty"] = $$createField17_0($$parsedSource["pagerduty"]);
+        }
+        return new NotificationChannel(/** @type {Partial<NotificationChannel>} */($$parsedSource));
+    }
+}
+
+/**
+ * Registro de una entrega de notificación
+ */
+export class NotificationDelivery {
+    /**
+     * Creates a new NotificationDelivery instance.
+     * @param {Partial<NotificationDelivery>} [$$source = {}] - The source object to create the NotificationDelivery.
+     */
+    constructor($$source = {}) {
+        if (!("id" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["id"] = 0;
+        }
+        if (!("channel" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["channel"] = "";
+        }
+        if (!("channelType" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["channelType"] = "";
+        }
+        if (!("siteName" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["siteName"] = "";
+        }
+        if (!("eventType" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["eventType"] = "";
+        }
+        if (!("incidentId" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["incidentId"] = 0;
+        }
+        if (!("status" in $$source)) {
+            /**
+             * "sent", "failed", "dropped"
+             * @member
+             * @type {string}
+             */
+            this["status"] = "";
+        }
+        if (!("attempts" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["attempts"] = 0;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {string | undefined}
+             */
+            this["payload"] = "";
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {string | undefined}
+             */
+            this["error"] = "";
+        }
+        if (!("createdAt" in $$source)) {
+            /**
+             * @member
+             * @type {time$0.Time}
+             */
+            this["createdAt"] = null;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new NotificationDelivery instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {NotificationDelivery}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new NotificationDelivery(/** @type {Partial<NotificationDelivery>} */($$parsedSource));
+    }
+}
+
+/**
+ * Evento enviado a los canales cuando un sitio cambia de estado o cuando
+ * una regla de alerta se activa o se resuelve
+ */
+export class NotificationEvent {
+    /**
+     * Creates a new NotificationEvent instance.
+     * @param {Partial<NotificationEvent>} [$$source = {}] - The source object to create the NotificationEvent.
+     */
+    constructor($$source = {}) {
+        if (!("type" in $$source)) {
+            /**
+             * "down", "recovery", "alert_firing", "alert_resolved", "reminder", "escalation", "flapping", "flapping_end"
+             * @member
+             * @type {string}
+             */
+            this["type"] = "";
+        }
+        if (!("site" in $$source)) {
+            /**
+             * @member
+             * @type {Site}
+             */
+            this["site"] = (new Site());
+        }
+        if (!("check" in $$source)) {
+            /**
+             * @member
+             * @type {StatusCheck}
+             */
+            this["check"] = (new StatusCheck());
+        }
+        if (!("incident" in $$source)) {
+            /**
+             * @member
+             * @type {Incident}
+             */
+            this["incident"] = (new Incident());
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {AlertInfo | null | undefined}
+             */
+            this["alert"] = null;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * el sitio oscila: no se notifica cada cambio
+             * @member
+             * @type {boolean | undefined}
+             */
+            this["flapping"] = false;
+        }
+        if (!("occurredAt" in $$source)) {
+            /**
+             * @member
+             * @type {time$0.Time}
+             */
+            this["occurredAt"] = null;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new NotificationEvent instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {NotificationEvent}
+     */
+    static createFrom($$source = {}) {
+        const $$createField1_0 = $$createType3;
+        const $$createField2_0 = $$createType35;
+        const $$createField3_0 = $$createType36;
+        const $$createField4_0 = $$createType38;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("site" in $$parsedSource) {
+            $$parsedSource["site"] = $$createField1_0($$parsedSource["site"]);
+        }
+        if ("check" in $$parsedSource) {
+            $$parsedSource["check"] = $$createField2_0($$parsedSource["check"]);
+        }
+        if ("incident" in $$parsedSource) {
+            $$parsedSource["incident"] = $$createField3_0($$parsedSource["incident"]);
+        }
+        if ("alert" in $$parsedSource) {
+            $$parsedSource["alert"] = $$createField4_0($$parsedSource["alert"]);
+        }
+        return new NotificationEvent(/** @type {Partial<NotificationEvent>} */($$parsedSource));
+    }
+}
+
+/**
+ * Resultado de renderizar las plantillas de un canal
+ */
+export class NotificationPreview {
+    /**
+     * Creates a new NotificationPreview instance.
+     * @param {Partial<NotificationPreview>} [$$source = {}] - The source object to create the NotificationPreview.
+     */
+    constructor($$source = {}) {
+        if (!("title" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["title"] = "";
+        }
+        if (!("message" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["message"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new NotificationPreview instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {NotificationPreview}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new NotificationPreview(/** @type {Partial<NotificationPreview>} */($$parsedSource));
+    }
+}
+
+/**
+ * Horario en que un canal envía notificaciones. Si End es menor que Start
+ * la ventana cruza la medianoche (ej. 22:00-06:00).
+ */
+export class NotificationSchedule {
+    /**
+     * Creates a new NotificationSchedule instance.
+     * @param {Partial<NotificationSchedule>} [$$source = {}] - The source object to create the NotificationSchedule.
+     */
+    constructor($$source = {}) {
+        if (/** @type {any} */(false)) {
+            /**
+             * zona IANA; vacío = hora local
+             * @member
+             * @type {string | undefined}
+             */
+            this["timezone"] = "";
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * 0 = domingo ... 6 = sábado; vacío = todos
+             * @member
+             * @type {number[] | undefined}
+             */
+            this["weekdays"] = [];
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * "HH:MM"; vacío = todo el día
+             * @member
+             * @type {string | undefined}
+             */
+            this["start"] = "";
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * "HH:MM"
+             * @member
+             * @type {string | undefined}
+             */
+            this["end"] = "";
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * Severidades de sitio que se envían siempre, incluso fuera de horario
+             * @member
+             * @type {string[] | undefined}
+             */
+            this["alwaysSeverities"] = [];
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * "drop" (por defecto), "delay", "fallback"
+             * @member
+             * @type {string | undefined}
+             */
+            this["outsideAction"] = "";
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {string | undefined}
+             */
+            this["fallbackChannel"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new NotificationSchedule instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {NotificationSchedule}
+     */
+    static createFrom($$source = {}) {
+        const $$createField1_0 = $$createType39;
+        const $$createField4_0 = $$createType0;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("weekdays" in $$parsedSource) {
+            $$parsedSource["weekdays"] = $$createField1_0($$parsedSource["weekdays"]);
+        }
+        if ("alwaysSeverities" in $$parsedSource) {
+            $$parsedSource["alwaysSeverities"] = $$createField4_0($$parsedSource["alwaysSeverities"]);
+        }
+        return new NotificationSchedule(/** @type {Partial<NotificationSchedule>} */($$parsedSource));
+    }
+}
+
+/**
+ * Plantillas text/template del título y del mensaje de un canal
+ */
+export class NotificationTemplates {
+    /**
+     * Creates a new NotificationTemplates instance.
+     * @param {Partial<NotificationTemplates>} [$$source = {}] - The source object to create the NotificationTemplates.
+     */
+    constructor($$source = {}) {
+        if (!("title" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["title"] = "";
+        }
+        if (!("message" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["message"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new NotificationTemplates instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {NotificationTemplates}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new NotificationTemplates(/** @type {Partial<NotificationTemplates>} */($$parsedSource));
+    }
+}
+
+/**
+ * Configuración de ntfy (ntfy.sh o servidor propio)
+ */
+export class NtfyConfig {
+    /**
+     * Creates a new NtfyConfig instance.
+     * @param {Partial<NtfyConfig>} [$$source = {}] - The source object to create the NtfyConfig.
+     */
+    constructor($$source = {}) {
+        if (/** @type {any} */(false)) {
+            /**
+             * por defecto https://ntfy.sh
+             * @member
+             * @type {string | undefined}
+             */
+            this["baseUrl"] = "";
+        }
+        if (!("topic" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["topic"] = "";
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * token de acceso para topics protegidos
+             * @member
+             * @type {string | undefined}
+             */
+            this["token"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new NtfyConfig instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {NtfyConfig}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new NtfyConfig(/** @type {Partial<NtfyConfig>} */($$parsedSource));
+    }
+}
+
+/**
+ * Configuración de PagerDuty Events API v2
+ */
+export class PagerDutyConfig {
+    /**
+     * Creates a new PagerDutyConfig instance.
+     * @param {Partial<PagerDutyConfig>} [$$source = {}] - The source object to create the PagerDutyConfig.
+     */
+    constructor($$source = {}) {
+        if (/** @type {any} */(false)) {
             /**
+             * por defecto https://events.pagerduty.com
              * @member
-             * @type {number}
+             * @type {string | undefined}
              */
-            this["upChecks"] = 0;
+            this["baseUrl"] = "";
         }
-        if (!("downChecks" in $$source)) {
+        if (!("routingKey" in $$source)) {
             /**
+             * integration key del servicio
              * @member
-             * @type {number}
+             * @type {string}
              */
-            this["downChecks"] = 0;
+            this["routingKey"] = "";
         }
-        if (!("uptimePercent" in $$source)) {
+        if (/** @type {any} */(false)) {
             /**
+             * "critical", "error", "warning", "info" (por defecto "critical")
              * @member
-             * @type {number}
+             * @type {string | undefined}
              */
-            this["uptimePercent"] = 0;
+            this["severity"] = "";
         }
 
         Object.assign(this, $$source);
     }
 
     /**
-     * Creates a new DailyStats instance from a string or object.
+     * Creates a new PagerDutyConfig instance from a string or object.
      * @param {any} [$$source = {}]
-     * @returns {DailyStats}
+     * @returns {PagerDutyConfig}
      */
     static createFrom($$source = {}) {
         let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
-        return new DailyStats(/** @type {Partial<DailyStats>} */($$parsedSource));
+        return new PagerDutyConfig(/** @type {Partial<PagerDutyConfig>} */($$parsedSource));
     }
 }
 
@@ -151,6 +2149,44 @@ export class Site {
              */
             this["timeout"] = 0;
         }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {string | undefined}
+             */
+            this["group"] = "";
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {string[] | undefined}
+             */
+            this["tags"] = [];
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * sitios de los que depende (ej. VPN)
+             * @member
+             * @type {string[] | undefined}
+             */
+            this["dependsOn"] = [];
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * sin notificaciones de escritorio
+             * @member
+             * @type {boolean | undefined}
+             */
+            this["muteDesktop"] = false;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * "critical", "normal" (por defecto), "low"
+             * @member
+             * @type {string | undefined}
+             */
+            this["severity"] = "";
+        }
 
         Object.assign(this, $$source);
     }
@@ -161,7 +2197,15 @@ export class Site {
      * @returns {Site}
      */
     static createFrom($$source = {}) {
+        const $$createField5_0 = $$createType0;
+        const $$createField6_0 = $$createType0;
         let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("tags" in $$parsedSource) {
+            $$parsedSource["tags"] = $$createField5_0($$parsedSource["tags"]);
+        }
+        if ("dependsOn" in $$parsedSource) {
+            $$parsedSource["dependsOn"] = $$createField6_0($$parsedSource["dependsOn"]);
+        }
         return new Site(/** @type {Partial<Site>} */($$parsedSource));
     }
 }
@@ -200,6 +2244,49 @@ export class SiteDetail {
              */
             this["timeout"] = 0;
         }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {string | undefined}
+             */
+            this["group"] = "";
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {string[] | undefined}
+             */
+            this["tags"] = [];
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {string[] | undefined}
+             */
+            this["dependsOn"] = [];
+        }
+        if (!("muteDesktop" in $$source)) {
+            /**
+             * @member
+             * @type {boolean}
+             */
+            this["muteDesktop"] = false;
+        }
+        if (!("severity" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["severity"] = "";
+        }
+        if (!("flapping" in $$source)) {
+            /**
+             * el estado cambia con demasiada frecuencia
+             * @member
+             * @type {boolean}
+             */
+            this["flapping"] = false;
+        }
         if (/** @type {any} */(false)) {
             /**
              * @member
@@ -252,7 +2339,15 @@ export class SiteDetail {
      * @returns {SiteDetail}
      */
     static createFrom($$source = {}) {
+        const $$createField5_0 = $$createType0;
+        const $$createField6_0 = $$createType0;
         let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("tags" in $$parsedSource) {
+            $$parsedSource["tags"] = $$createField5_0($$parsedSource["tags"]);
+        }
+        if ("dependsOn" in $$parsedSource) {
+            $$parsedSource["dependsOn"] = $$createField6_0($$parsedSource["dependsOn"]);
+        }
         return new SiteDetail(/** @type {Partial<SiteDetail>} */($$parsedSource));
     }
 }
@@ -280,6 +2375,20 @@ export class SiteStatusDetail {
              */
             this["siteUrl"] = "";
         }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {string | undefined}
+             */
+            this["group"] = "";
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {string[] | undefined}
+             */
+            this["tags"] = [];
+        }
         if (!("lastStatus" in $$source)) {
             /**
              * @member
@@ -339,14 +2448,18 @@ export class SiteStatusDetail {
      * @returns {SiteStatusDetail}
      */
     static createFrom($$source = {}) {
-        const $$createField7_0 = $$createType3;
-        const $$createField8_0 = $$createType2;
+        const $$createField3_0 = $$createType0;
+        const $$createField9_0 = $$createType41;
+        const $$createField10_0 = $$createType40;
         let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("tags" in $$parsedSource) {
+            $$parsedSource["tags"] = $$createField3_0($$parsedSource["tags"]);
+        }
         if ("dailyStats" in $$parsedSource) {
-            $$parsedSource["dailyStats"] = $$createField7_0($$parsedSource["dailyStats"]);
+            $$parsedSource["dailyStats"] = $$createField9_0($$parsedSource["dailyStats"]);
         }
         if ("totalStats" in $$parsedSource) {
-            $$parsedSource["totalStats"] = $$createField8_0($$parsedSource["totalStats"]);
+            $$parsedSource["totalStats"] = $$createField10_0($$parsedSource["totalStats"]);
         }
         return new SiteStatusDetail(/** @type {Partial<SiteStatusDetail>} */($$parsedSource));
     }
@@ -381,7 +2494,7 @@ export class StatusCheck {
         }
         if (!("status" in $$source)) {
             /**
-             * "up", "down"
+             * "up", "down", "unreachable"
              * @member
              * @type {string}
              */
@@ -416,6 +2529,14 @@ export class StatusCheck {
              */
             this["errorMessage"] = "";
         }
+        if (/** @type {any} */(false)) {
+            /**
+             * expiración del certificado TLS
+             * @member
+             * @type {time$0.Time | null | undefined}
+             */
+            this["certExpiresAt"] = null;
+        }
 
         Object.assign(this, $$source);
     }
@@ -431,8 +2552,281 @@ export class StatusCheck {
     }
 }
 
+/**
+ * Estructura con el status de todos los sitios y el agregado por grupo
+ */
+export class StatusOverview {
+    /**
+     * Creates a new StatusOverview instance.
+     * @param {Partial<StatusOverview>} [$$source = {}] - The source object to create the StatusOverview.
+     */
+    constructor($$source = {}) {
+        if (!("groups" in $$source)) {
+            /**
+             * @member
+             * @type {GroupStatusDetail[]}
+             */
+            this["groups"] = [];
+        }
+        if (!("sites" in $$source)) {
+            /**
+             * @member
+             * @type {SiteStatusDetail[]}
+             */
+            this["sites"] = [];
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new StatusOverview instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {StatusOverview}
+     */
+    static createFrom($$source = {}) {
+        const $$createField0_0 = $$createType43;
+        const $$createField1_0 = $$createType45;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("groups" in $$parsedSource) {
+            $$parsedSource["groups"] = $$createField0_0($$parsedSource["groups"]);
+        }
+        if ("sites" in $$parsedSource) {
+            $$parsedSource["sites"] = $$createField1_0($$parsedSource["sites"]);
+        }
+        return new StatusOverview(/** @type {Partial<StatusOverview>} */($$parsedSource));
+    }
+}
+
+/**
+ * Configuración de la página de estado pública
+ */
+export class StatusPageConfig {
+    /**
+     * Creates a new StatusPageConfig instance.
+     * @param {Partial<StatusPageConfig>} [$$source = {}] - The source object to create the StatusPageConfig.
+     */
+    constructor($$source = {}) {
+        if (/** @type {any} */(false)) {
+            /**
+             * por defecto "Estado de los servicios"
+             * @member
+             * @type {string | undefined}
+             */
+            this["title"] = "";
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {string | undefined}
+             */
+            this["description"] = "";
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {string | undefined}
+             */
+            this["logoUrl"] = "";
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * URL base de los enlaces de los feeds; por defecto la del request
+             * @member
+             * @type {string | undefined}
+             */
+            this["publicUrl"] = "";
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * sitios visibles ("*" = todos); vacío junto a PublicGroups = ninguno
+             * @member
+             * @type {string[] | undefined}
+             */
+            this["publicSites"] = [];
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * grupos cuyos sitios son visibles
+             * @member
+             * @type {string[] | undefined}
+             */
+            this["publicGroups"] = [];
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new StatusPageConfig instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {StatusPageConfig}
+     */
+    static createFrom($$source = {}) {
+        const $$createField4_0 = $$createType0;
+        const $$createField5_0 = $$createType0;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("publicSites" in $$parsedSource) {
+            $$parsedSource["publicSites"] = $$createField4_0($$parsedSource["publicSites"]);
+        }
+        if ("publicGroups" in $$parsedSource) {
+            $$parsedSource["publicGroups"] = $$createField5_0($$parsedSource["publicGroups"]);
+        }
+        return new StatusPageConfig(/** @type {Partial<StatusPageConfig>} */($$parsedSource));
+    }
+}
+
+/**
+ * Configuración de un bot de Telegram
+ */
+export class TelegramConfig {
+    /**
+     * Creates a new TelegramConfig instance.
+     * @param {Partial<TelegramConfig>} [$$source = {}] - The source object to create the TelegramConfig.
+     */
+    constructor($$source = {}) {
+        if (/** @type {any} */(false)) {
+            /**
+             * por defecto https://api.telegram.org
+             * @member
+             * @type {string | undefined}
+             */
+            this["baseUrl"] = "";
+        }
+        if (!("botToken" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["botToken"] = "";
+        }
+        if (!("chatId" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["chatId"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new TelegramConfig instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {TelegramConfig}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new TelegramConfig(/** @type {Partial<TelegramConfig>} */($$parsedSource));
+    }
+}
+
+/**
+ * Configuración de un canal webhook genérico
+ */
+export class WebhookConfig {
+    /**
+     * Creates a new WebhookConfig instance.
+     * @param {Partial<WebhookConfig>} [$$source = {}] - The source object to create the WebhookConfig.
+     */
+    constructor($$source = {}) {
+        if (!("url" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["url"] = "";
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * POST por defecto
+             * @member
+             * @type {string | undefined}
+             */
+            this["method"] = "";
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {{ [_: string]: string } | undefined}
+             */
+            this["headers"] = {};
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * Plantilla text/template del cuerpo JSON. Recibe los campos del
+             * NotificationEvent, .Title y .Message ya renderizados, y dispone de la
+             * función "json" para escapar valores.
+             * @member
+             * @type {string | undefined}
+             */
+            this["template"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new WebhookConfig instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {WebhookConfig}
+     */
+    static createFrom($$source = {}) {
+        const $$createField2_0 = $$createType46;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("headers" in $$parsedSource) {
+            $$parsedSource["headers"] = $$createField2_0($$parsedSource["headers"]);
+        }
+        return new WebhookConfig(/** @type {Partial<WebhookConfig>} */($$parsedSource));
+    }
+}
+
 // Private type creation functions
-const $$createType0 = Site.createFrom;
-const $$createType1 = $Create.Array($$createType0);
-const $$createType2 = DailyStats.createFrom;
-const $$createType3 = $Create.Array($$createType2);
+const $$createType0 = $Create.Array($Create.Any);
+const $$createType1 = Group.createFrom;
+const $$createType2 = $Create.Array($$createType1);
+const $$createType3 = Site.createFrom;
+const $$createType4 = $Create.Array($$createType3);
+const $$createType5 = NotificationChannel.createFrom;
+const $$createType6 = $Create.Array($$createType5);
+const $$createType7 = DesktopNotificationConfig.createFrom;
+const $$createType8 = AlertRule.createFrom;
+const $$createType9 = $Create.Array($$createType8);
+const $$createType10 = EscalationPolicy.createFrom;
+const $$createType11 = $Create.Array($$createType10);
+const $$createType12 = FlappingConfig.createFrom;
+const $$createType13 = HTTPServerConfig.createFrom;
+const $$createType14 = StatusPageConfig.createFrom;
+const $$createType15 = IncidentUpdate.createFrom;
+const $$createType16 = $Create.Array($$createType15);
+const $$createType17 = NotificationSchedule.createFrom;
+const $$createType18 = $Create.Nullable($$createType17);
+const $$createType19 = NotificationTemplates.createFrom;
+const $$createType20 = $Create.Nullable($$createType19);
+const $$createType21 = WebhookConfig.createFrom;
+const $$createType22 = $Create.Nullable($$createType21);
+const $$createType23 = EmailConfig.createFrom;
+const $$createType24 = $Create.Nullable($$createType23);
+const $$createType25 = ChatConfig.createFrom;
+const $$createType26 = $Create.Nullable($$createType25);
+const $$createType27 = TelegramConfig.createFrom;
+const $$createType28 = $Create.Nullable($$createType27);
+const $$createType29 = NtfyConfig.createFrom;
+const $$createType30 = $Create.Nullable($$createType29);
+const $$createType31 = GotifyConfig.createFrom;
+const $$createType32 = $Create.Nullable($$createType31);
+const $$createType33 = PagerDutyConfig.createFrom;
+const $$createType34 = $Create.Nullable($$createType33);
+const $$createType35 = StatusCheck.createFrom;
+const $$createType36 = Incident.createFrom;
+const $$createType37 = AlertInfo.createFrom;
+const $$createType38 = $Create.Nullable($$createType37);
+const $$createType39 = $Create.Array($Create.Any);
+const $$createType40 = DailyStats.createFrom;
+const $$createType41 = $Create.Array($$createType40);
+const $$createType42 = GroupStatusDetail.createFrom;
+const $$createType43 = $Create.Array($$createType42);
+const $$createType44 = SiteStatusDetail.createFrom;
+const $$createType45 = $Create.Array($$createType44);
+const $$createType46 = $Create.Map($Create.Any, $Create.Any);
@@ -10,6 +10,33 @@ import {Call as $Call, Create as $Create} from "@wailsio/runtime";
 // @ts-ignore: Unused imports
 import * as $models from "./models.js";
 
+/**
+ * AcknowledgeIncident marca el incidente abierto como reconocido, lo que
+ * detiene los recordatorios y el escalado
+ * @param {number} id
+ * @returns {Promise<void> & { cancel(): void }}
+ */
+export function AcknowledgeIncident(id) {
+    let $resultPromise = /** @type {any} */($Call.ByID(1103130502, id));
+    return $resultPromise;
+}
+
+/**
+ * AddIncidentUpdate publica una actualización y cambia el estado del incidente
+ * @param {number} id
+ * @param {string} status
+ * @param {string} message
+ * @returns {Promise<$models.ManualIncident | null> & { cancel(): void }}
+ */
+export function AddIncidentUpdate(id, status, message) {
+    let $resultPromise = /** @type {any} */($Call.ByID(4058899308, id, status, message));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType1($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
 /**
  * @param {string} name
  * @param {string} url
@@ -22,6 +49,16 @@ export function AddSite(name, url, method, timeout) {
     return $resultPromise;
 }
 
+/**
+ * CancelMaintenance elimina una ventana de mantenimiento
+ * @param {number} id
+ * @returns {Promise<void> & { cancel(): void }}
+ */
+export function CancelMaintenance(id) {
+    let $resultPromise = /** @type {any} */($Call.ByID(3494004609, id));
+    return $resultPromise;
+}
+
 /**
  * Verificar conectividad a internet
  * @returns {Promise<boolean> & { cancel(): void }}
@@ -32,10 +69,31 @@ export function CheckInternetConnectivity() {
 }
 
 /**
- * @returns {Promise<$models.SiteDetail[]> & { cancel(): void }}
+ * CreateAPIToken genera un token con el alcance indicado ("read", "write" o "admin")
+ * @param {string} name
+ * @param {string} scope
+ * @returns {Promise<$models.NewAPIToken | null> & { cancel(): void }}
  */
-export function GetAllSites() {
-    let $resultPromise = /** @type {any} */($Call.ByID(1742193065));
+export function CreateAPIToken(name, scope) {
+    let $resultPromise = /** @type {any} */($Call.ByID(1791023693, name, scope));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType3($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * CreateManualIncident publica un incidente con su primera actualización
+ * @param {string} title
+ * @param {string} status
+ * @param {string} message
+ * @param {string[]} sites
+ * @param {string[]} groups
+ * @returns {Promise<$models.ManualIncident | null> & { cancel(): void }}
+ */
+export function CreateManualIncident(title, status, message, sites, groups) {
+    let $resultPromise = /** @type {any} */($Call.ByID(1269077584, title, status, message, sites, groups));
     let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
         return $$createType1($result);
     }));
@@ -43,14 +101,70 @@ export function GetAllSites() {
     return $typingPromise;
 }
 
+/**
+ * ExportHistory exporta el historial de checks de los sitios indicados (todos si
+ * está vacío) entre from y to ("2006-01-02" o RFC 3339, vacíos sin límite) al
+ * archivo elegido en un diálogo de guardado. Devuelve la ruta, o "" si se canceló.
+ * @param {string[]} siteNames
+ * @param {string} $from
+ * @param {string} to
+ * @param {string} format
+ * @returns {Promise<string> & { cancel(): void }}
+ */
+export function ExportHistory(siteNames, $from, to, format) {
+    let $resultPromise = /** @type {any} */($Call.ByID(475988698, siteNames, $from, to, format));
+    return $resultPromise;
+}
+
+/**
+ * GetAPITokens devuelve los tokens creados (sin su valor)
+ * @returns {Promise<$models.APIToken[]> & { cancel(): void }}
+ */
+export function GetAPITokens() {
+    let $resultPromise = /** @type {any} */($Call.ByID(457630138));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType5($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * GetAlertStates devuelve el estado de todas las reglas evaluadas
+ * @returns {Promise<$models.AlertState[]> & { cancel(): void }}
+ */
+export function GetAlertStates() {
+    let $resultPromise = /** @type {any} */($Call.ByID(3757660504));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType7($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * GetAllSites devuelve los sitios configurados. Si tag no está vacío solo
+ * se incluyen los sitios que tengan esa etiqueta.
+ * @param {string} tag
+ * @returns {Promise<$models.SiteDetail[]> & { cancel(): void }}
+ */
+export function GetAllSites(tag) {
+    let $resultPromise = /** @type {any} */($Call.ByID(1742193065, tag));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType9($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
 /**
  * Métodos expuestos al frontend
- * @returns {Promise<$models.SiteStatusDetail[]> & { cancel(): void }}
+ * @returns {Promise<$models.StatusOverview | null> & { cancel(): void }}
  */
 export function GetAllStatus() {
     let $resultPromise = /** @type {any} */($Call.ByID(3889932381));
     let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
-        return $$createType3($result);
+        return $$createType11($result);
     }));
     $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
     return $typingPromise;
@@ -62,7 +176,87 @@ export function GetAllStatus() {
 export function GetConfig() {
     let $resultPromise = /** @type {any} */($Call.ByID(1765635878));
     let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
-        return $$createType4($result);
+        return $$createType12($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * @returns {Promise<boolean> & { cancel(): void }}
+ */
+export function GetDoNotDisturb() {
+    let $resultPromise = /** @type {any} */($Call.ByID(1702298781));
+    return $resultPromise;
+}
+
+/**
+ * GetIncidents devuelve los últimos incidentes; si siteName está vacío se incluyen todos los sitios
+ * @param {string} siteName
+ * @param {number} limit
+ * @returns {Promise<$models.Incident[]> & { cancel(): void }}
+ */
+export function GetIncidents(siteName, limit) {
+    let $resultPromise = /** @type {any} */($Call.ByID(3112032991, siteName, limit));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType14($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * GetMaintenances devuelve las ventanas de mantenimiento en curso y programadas;
+ * con includePast también las ya terminadas
+ * @param {boolean} includePast
+ * @returns {Promise<$models.Maintenance[]> & { cancel(): void }}
+ */
+export function GetMaintenances(includePast) {
+    let $resultPromise = /** @type {any} */($Call.ByID(1829594420, includePast));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType16($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * GetManualIncident devuelve un incidente manual con sus actualizaciones (más reciente primero)
+ * @param {number} id
+ * @returns {Promise<$models.ManualIncident | null> & { cancel(): void }}
+ */
+export function GetManualIncident(id) {
+    let $resultPromise = /** @type {any} */($Call.ByID(349880432, id));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType1($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * GetManualIncidents devuelve los últimos incidentes manuales con sus actualizaciones
+ * @param {number} limit
+ * @returns {Promise<$models.ManualIncident[]> & { cancel(): void }}
+ */
+export function GetManualIncidents(limit) {
+    let $resultPromise = /** @type {any} */($Call.ByID(3613148345, limit));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType17($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * GetNotificationLog devuelve las últimas entregas de notificaciones
+ * @param {number} limit
+ * @returns {Promise<$models.NotificationDelivery[]> & { cancel(): void }}
+ */
+export function GetNotificationLog(limit) {
+    let $resultPromise = /** @type {any} */($Call.ByID(1723942135, limit));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType19($result);
     }));
     $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
     return $typingPromise;
@@ -75,7 +269,7 @@ export function GetConfig() {
 export function GetSiteStatus(siteName) {
     let $resultPromise = /** @type {any} */($Call.ByID(2335009477, siteName));
     let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
-        return $$createType6($result);
+        return $$createType21($result);
     }));
     $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
     return $typingPromise;
@@ -87,7 +281,7 @@ export function GetSiteStatus(siteName) {
 export function GetStats() {
     let $resultPromise = /** @type {any} */($Call.ByID(3336061195));
     let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
-        return $$createType7($result);
+        return $$createType22($result);
     }));
     $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
     return $typingPromise;
@@ -102,6 +296,43 @@ export function ManualCheck(siteName) {
     return $resultPromise;
 }
 
+/**
+ * NotifyDesktop muestra el evento como notificación nativa si está permitido
+ * @param {$models.NotificationEvent} event
+ * @returns {Promise<void> & { cancel(): void }}
+ */
+export function NotifyDesktop(event) {
+    let $resultPromise = /** @type {any} */($Call.ByID(4049910243, event));
+    return $resultPromise;
+}
+
+/**
+ * OnTransition registra una función que se ejecuta en cada caída o
+ * recuperación de un sitio (ej. notificaciones de escritorio en la GUI)
+ * @param {any} listener
+ * @returns {Promise<void> & { cancel(): void }}
+ */
+export function OnTransition(listener) {
+    let $resultPromise = /** @type {any} */($Call.ByID(2701473538, listener));
+    return $resultPromise;
+}
+
+/**
+ * PreviewNotification renderiza el título y el mensaje que enviaría el canal
+ * (aunque aún no esté guardado). Si el evento no indica sitio se usa uno de ejemplo.
+ * @param {$models.NotificationChannel} channel
+ * @param {$models.NotificationEvent} event
+ * @returns {Promise<$models.NotificationPreview> & { cancel(): void }}
+ */
+export function PreviewNotification(channel, event) {
+    let $resultPromise = /** @type {any} */($Call.ByID(1140115335, channel, event));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType23($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
 /**
  * @param {string} name
  * @returns {Promise<void> & { cancel(): void }}
@@ -111,6 +342,85 @@ export function RemoveSite(name) {
     return $resultPromise;
 }
 
+/**
+ * RevokeAPIToken revoca un token; las peticiones que lo usen serán rechazadas
+ * @param {number} id
+ * @returns {Promise<void> & { cancel(): void }}
+ */
+export function RevokeAPIToken(id) {
+    let $resultPromise = /** @type {any} */($Call.ByID(888365635, id));
+    return $resultPromise;
+}
+
+/**
+ * ScheduleMaintenance programa una ventana de mantenimiento
+ * @param {$models.Maintenance} m
+ * @returns {Promise<$models.Maintenance | null> & { cancel(): void }}
+ */
+export function ScheduleMaintenance(m) {
+    let $resultPromise = /** @type {any} */($Call.ByID(2140420988, m));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType24($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * SetDoNotDisturb activa o desactiva el modo "no molestar"
+ * @param {boolean} enabled
+ * @returns {Promise<void> & { cancel(): void }}
+ */
+export function SetDoNotDisturb(enabled) {
+    let $resultPromise = /** @type {any} */($Call.ByID(3071053433, enabled));
+    return $resultPromise;
+}
+
+/**
+ * SetSiteDependencies define los sitios de los que depende un sitio
+ * @param {string} name
+ * @param {string[]} dependsOn
+ * @returns {Promise<void> & { cancel(): void }}
+ */
+export function SetSiteDependencies(name, dependsOn) {
+    let $resultPromise = /** @type {any} */($Call.ByID(3242549210, name, dependsOn));
+    return $resultPromise;
+}
+
+/**
+ * SetSiteDesktopMute silencia las notificaciones de escritorio de un sitio
+ * @param {string} name
+ * @param {boolean} muted
+ * @returns {Promise<void> & { cancel(): void }}
+ */
+export function SetSiteDesktopMute(name, muted) {
+    let $resultPromise = /** @type {any} */($Call.ByID(3299900756, name, muted));
+    return $resultPromise;
+}
+
+/**
+ * SetSiteGroup asigna el grupo y las etiquetas de un sitio
+ * @param {string} name
+ * @param {string} group
+ * @param {string[]} tags
+ * @returns {Promise<void> & { cancel(): void }}
+ */
+export function SetSiteGroup(name, group, tags) {
+    let $resultPromise = /** @type {any} */($Call.ByID(4040634038, name, group, tags));
+    return $resultPromise;
+}
+
+/**
+ * SetSiteSeverity cambia la severidad del sitio ("critical", "normal", "low")
+ * @param {string} name
+ * @param {string} severity
+ * @returns {Promise<void> & { cancel(): void }}
+ */
+export function SetSiteSeverity(name, severity) {
+    let $resultPromise = /** @type {any} */($Call.ByID(1048835200, name, severity));
+    return $resultPromise;
+}
+
 /**
  * @returns {Promise<void> & { cancel(): void }}
  */
@@ -128,6 +438,30 @@ export function StartWithConnectivityCheck() {
     return $resultPromise;
 }
 
+/**
+ * TestNotificationChannel envía un evento de prueba al canal indicado
+ * @param {string} channelName
+ * @returns {Promise<$models.NotificationDelivery> & { cancel(): void }}
+ */
+export function TestNotificationChannel(channelName) {
+    let $resultPromise = /** @type {any} */($Call.ByID(3702661526, channelName));
+    let $typingPromise = /** @type {any} */($resultPromise.then(($result) => {
+        return $$createType18($result);
+    }));
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * UpdateAlertRules reemplaza las reglas de alerta después de validarlas
+ * @param {$models.AlertRule[]} rules
+ * @returns {Promise<void> & { cancel(): void }}
+ */
+export function UpdateAlertRules(rules) {
+    let $resultPromise = /** @type {any} */($Call.ByID(3370849372, rules));
+    return $resultPromise;
+}
+
 /**
  * @param {number} checkInterval
  * @param {number} retentionDays
@@ -138,6 +472,30 @@ export function UpdateConfig(checkInterval, retentionDays) {
     return $resultPromise;
 }
 
+/**
+ * UpdateGroups reemplaza la lista de grupos; el orden recibido es el que se
+ * guarda en config.json y se usa en GetAllStatus
+ * @param {$models.Group[]} groups
+ * @returns {Promise<void> & { cancel(): void }}
+ */
+export function UpdateGroups(groups) {
+    let $resultPromise = /** @type {any} */($Call.ByID(168055637, groups));
+    return $resultPromise;
+}
+
+/**
+ * UpdateSite modifica la URL, el método y el timeout de un sitio
+ * @param {string} name
+ * @param {string} url
+ * @param {string} method
+ * @param {number} timeout
+ * @returns {Promise<void> & { cancel(): void }}
+ */
+export function UpdateSite(name, url, method, timeout) {
+    let $resultPromise = /** @type {any} */($Call.ByID(559284630, name, url, method, timeout));
+    return $resultPromise;
+}
+
 /**
  * Esperar hasta que la conectividad a internet esté disponible
  * @returns {Promise<void> & { cancel(): void }}
@@ -148,11 +506,28 @@ export function WaitForInternetConnectivity() {
 }
 
 // Private type creation functions
-const $$createType0 = $models.SiteDetail.createFrom;
-const $$createType1 = $Create.Array($$createType0);
-const $$createType2 = $models.SiteStatusDetail.createFrom;
-const $$createType3 = $Create.Array($$createType2);
-const $$createType4 = $models.Config.createFrom;
-const $$createType5 = $models.StatusCheck.createFrom;
-const $$createType6 = $Create.Array($$createType5);
-const $$createType7 = $Create.Map($Create.Any, $Create.Any);
+const $$createType0 = $models.ManualIncident.createFrom;
+const $$createType1 = $Create.Nullable($$createType0);
+const $$createType2 = $models.NewAPIToken.createFrom;
+const $$createType3 = $Create.Nullable($$createType2);
+const $$createType4 = $models.APIToken.createFrom;
+const $$createType5 = $Create.Array($$createType4);
+const $$createType6 = $models.AlertState.createFrom;
+const $$createType7 = $Create.Array($$createType6);
+const $$createType8 = $models.SiteDetail.createFrom;
+const $$createType9 = $Create.Array($$createType8);
+const $$createType10 = $models.StatusOverview.createFrom;
+const $$createType11 = $Create.Nullable($$createType10);
+const $$createType12 = $models.Config.createFrom;
+const $$createType13 = $models.Incident.createFrom;
+const $$createType14 = $Create.Array($$createType13);
+const $$createType15 = $models.Maintenance.createFrom;
+const $$createType16 = $Create.Array($$createType15);
+const $$createType17 = $Create.Array($$createType0);
+const $$createType18 = $models.NotificationDelivery.createFrom;
+const $$createType19 = $Create.Array($$createType18);
+const $$createType20 = $models.StatusCheck.createFrom;
+const $$createType21 = $Create.Array($$createType20);
+const $$createType22 = $Create.Map($Create.Any, $Create.Any);
+const $$createType23 = $models.NotificationPreview.createFrom;
+const $$createType24 = $Create.Nullable($$createType15);
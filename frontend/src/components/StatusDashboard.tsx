@@ -23,8 +23,9 @@ import {
 } from 'antd';
 import dayjs from 'dayjs';
 import React, { useEffect, useState } from 'react';
+import { Events } from '@wailsio/runtime';
 import { StatusPageService } from '../../bindings/changeme';
-import { SiteDetail, SiteStatusDetail } from '../types';
+import { BusEvent, Incident, SiteDetail, SiteStatusDetail } from '../types';
 import './StatusDashboard.css';
 
 const { Title, Text } = Typography;
@@ -36,6 +37,7 @@ const StatusDashboard: React.FC<Props> = () => {
     const [config, setConfig] = useState<any>(null);
     const [timelineDays, setTimelineDays] = useState(7); const [sites, setSites] = useState<SiteDetail[]>([]);
     const [siteStatusDetails, setSiteStatusDetails] = useState<SiteStatusDetail[]>([]);
+    const [openIncidents, setOpenIncidents] = useState<Record<string, Incident>>({});
     const [loading, setLoading] = useState(true);
     const [loadingCards, setLoadingCards] = useState<Set<string>>(new Set());
     const [modalOpen, setModalOpen] = useState(false);
@@ -43,12 +45,22 @@ const StatusDashboard: React.FC<Props> = () => {
     const loadData = async () => {
         try {
             setLoading(true);
-            const [sitesData, statusData] = await Promise.all([
-                StatusPageService.GetAllSites(),
-                StatusPageService.GetAllStatus()
+            const [sitesData, statusData, incidentsData] = await Promise.all([
+                StatusPageService.GetAllSites(""),
+                StatusPageService.GetAllStatus(),
+                StatusPageService.GetIncidents("", 50)
             ]);
             setSites(sitesData);
-            setSiteStatusDetails(statusData);
+            setSiteStatusDetails(statusData?.sites ?? []);
+
+            // Incidente abierto más reciente de cada sitio (vienen ordenados por inicio descendente)
+            const open: Record<string, Incident> = {};
+            for (const incident of (incidentsData ?? []) as Incident[]) {
+                if (!incident.resolvedAt && !open[incident.siteName]) {
+                    open[incident.siteName] = incident;
+                }
+            }
+            setOpenIncidents(open);
         } catch (error) {
             console.error('Error loading data:', error);
         } finally {
@@ -81,6 +93,16 @@ const StatusDashboard: React.FC<Props> = () => {
         }
     };
 
+    // Reconocer la caída detiene los recordatorios y el escalado del incidente
+    const handleAcknowledge = async (incidentId: number) => {
+        try {
+            await StatusPageService.AcknowledgeIncident(incidentId);
+            loadData();
+        } catch (error) {
+            console.error('Error reconociendo incidente:', error);
+        }
+    };
+
     const handleShowDetails = (siteName: string) => {
         setSelectedSite(siteName);
         setModalOpen(true);
@@ -95,9 +117,54 @@ const StatusDashboard: React.FC<Props> = () => {
         loadConfig();
         loadData();
 
-        // Actualizar datos cada 30 segundos
-        const interval = setInterval(loadData, 30000);
-        return () => clearInterval(interval);
+        // Recarga completa periódica para las estadísticas diarias; el estado
+        // de cada sitio llega en vivo con los eventos del servicio
+        const interval = setInterval(loadData, 300000);
+
+        // Actualizar la tarjeta del sitio con cada check sin recargar todo
+        const offCheck = Events.On('status:check', (event: any) => {
+            const busEvent: BusEvent = Array.isArray(event.data) ? event.data[0] : event.data;
+            const check = busEvent?.check;
+            if (!check) {
+                return;
+            }
+            setSites(prev => prev.map(site => site.name === check.siteName ? {
+                ...site,
+                status: check.status,
+                statusCode: check.statusCode,
+                responseTime: check.responseTime,
+                lastChecked: check.checkedAt,
+                errorMessage: check.errorMessage,
+            } : site));
+            setSiteStatusDetails(prev => prev.map(detail => detail.siteName === check.siteName ? {
+                ...detail,
+                lastStatus: check.status,
+                lastStatusCode: check.statusCode,
+                lastResponseTime: check.responseTime,
+                lastChecked: check.checkedAt,
+                lastErrorMessage: check.errorMessage,
+            } : detail));
+        });
+
+        // Las caídas y recuperaciones cambian incidentes y estadísticas: recargar
+        const offTransition = Events.On('status:transition', () => {
+            loadData();
+        });
+
+        // Abrir el detalle de un sitio seleccionado desde la bandeja del sistema
+        const offSelectSite = Events.On('site:select', (event: any) => {
+            const siteName = Array.isArray(event.data) ? event.data[0] : event.data;
+            if (siteName) {
+                handleShowDetails(siteName);
+            }
+        });
+
+        return () => {
+            clearInterval(interval);
+            offSelectSite();
+            offCheck();
+            offTransition();
+        };
     }, []);
 
     const loadConfig = async () => {
@@ -303,13 +370,20 @@ const StatusDashboard: React.FC<Props> = () => {
                                                         key: 'showDetails',
                                                         label: 'Ver detalles',
                                                         onClick: () => handleShowDetails(site.name)
-                                                    }
+                                                    },
+                                                    ...(openIncidents[site.name] ? [{
+                                                        key: 'acknowledge',
+                                                        label: openIncidents[site.name].acknowledgedAt ? 'Caída reconocida' : 'Reconocer caída',
+                                                        onClick: () => handleAcknowledge(openIncidents[site.name].id),
+                                                        disabled: !!openIncidents[site.name].acknowledgedAt
+                                                    }] : [])
                                                 ]
                                             }}
                                         >
                                             <Button type="link" icon={<MoreOutlined />} />
                                         </Dropdown>
                                         {getStatusTag(site.status)}
+                                        {site.flapping && <Tag color="warning">Oscilando</Tag>}
                                     </div>}
                                     size="small"                                >
                                     <Space direction="vertical" style={{ width: '100%' }} size="small">
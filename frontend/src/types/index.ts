@@ -14,12 +14,15 @@ export interface DailyStats {
     totalChecks: number;
     upChecks: number;
     downChecks: number;
+    unreachableChecks: number;
     uptimePercent: number;
 }
 
 export interface SiteStatusDetail {
     siteName: string;
     siteUrl: string;
+    group?: string;
+    tags?: string[];
     lastStatus: string;
     lastStatusCode: number;
     lastResponseTime: number;
@@ -34,6 +37,12 @@ export interface SiteDetail {
     url: string;
     method: string;
     timeout: number;
+    group?: string;
+    tags?: string[];
+    dependsOn?: string[];
+    muteDesktop: boolean;
+    severity: string;
+    flapping: boolean;
     status?: string;
     statusCode?: number;
     responseTime?: number;
@@ -42,9 +51,71 @@ export interface SiteDetail {
     isActive: boolean;
 }
 
+export interface StatusCheck {
+    id: number;
+    siteName: string;
+    siteUrl: string;
+    status: string;
+    statusCode: number;
+    responseTime: number;
+    checkedAt: string;
+    errorMessage?: string;
+    certExpiresAt?: string;
+}
+
+export interface Incident {
+    id: number;
+    siteName: string;
+    startedAt: string;
+    resolvedAt?: string;
+    errorMessage?: string;
+    acknowledgedAt?: string;
+    escalatedAt?: string;
+    lastReminderAt?: string;
+}
+
+// Evento del bus interno (Wails "status:check" / "status:transition" y /api/v1/events)
+export interface BusEvent {
+    type: 'check' | 'transition';
+    site: string;
+    group?: string;
+    check?: StatusCheck;
+    transition?: {
+        event: string;
+        summary: string;
+        incidentId?: number;
+        flapping?: boolean;
+    };
+    time: string;
+}
+
+export interface Group {
+    name: string;
+    description?: string;
+}
+
+export interface GroupStatusDetail {
+    name: string;
+    description?: string;
+    status: string;
+    totalSites: number;
+    upSites: number;
+    downSites: number;
+    totalChecks: number;
+    upChecks: number;
+    uptimePercent: number;
+    sites: string[];
+}
+
+export interface StatusOverview {
+    groups: GroupStatusDetail[];
+    sites: SiteStatusDetail[];
+}
+
 export interface Config {
     checkInterval: number;
     retentionDays: number;
+    groups?: Group[];
     sites: Site[];
 }
 
@@ -53,4 +124,9 @@ export interface Site {
     url: string;
     method: string;
     timeout: number;
+    group?: string;
+    tags?: string[];
+    dependsOn?: string[];
+    muteDesktop?: boolean;
+    severity?: string;
 }
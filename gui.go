@@ -0,0 +1,96 @@
+//go:build !headless
+
+package main
+
+import (
+	"context"
+	"embed"
+	_ "embed"
+	"log"
+
+	"github.com/wailsapp/wails/v3/pkg/application"
+)
+
+//go:embed all:frontend/dist
+var assets embed.FS
+
+// guiAvailable indica si el binario incluye la interfaz de escritorio
+const guiAvailable = true
+
+// Eventos de Wails con los checks y transiciones del bus interno
+const (
+	eventStatusCheck      = "status:check"
+	eventStatusTransition = "status:transition"
+)
+
+// runGUI inicia la aplicación de escritorio con el servicio de monitoreo
+func runGUI(statusService *StatusPageService) {
+	app := application.New(application.Options{
+		Name:        "StatusPage Monitor",
+		Description: "Monitor de estado de sitios web",
+		Services: []application.Service{
+			application.NewService(statusService),
+		},
+		Assets: application.AssetOptions{
+			Handler: application.AssetFileServerFS(assets),
+		},
+		Mac: application.MacOptions{
+			ActivationPolicy: application.ActivationPolicyAccessory,
+		},
+	})
+
+	// Iniciar el servicio de monitoreo
+	statusService.Start(context.Background())
+
+	window := app.NewWebviewWindowWithOptions(application.WebviewWindowOptions{
+		Title:       "StatusPage Monitor",
+		Width:       1100,
+		Height:      700,
+		Frameless:   true,
+		AlwaysOnTop: true,
+		Hidden:      true,
+		Windows: application.WindowsWindow{
+			HiddenOnTaskbar: true,
+		},
+		BackgroundColour: application.NewRGB(248, 249, 250),
+		URL:              "/",
+	})
+
+	// Notificaciones de escritorio en caídas y recuperaciones
+	statusService.OnTransition(statusService.NotifyDesktop)
+
+	// Checks y transiciones en vivo para la UI (evita recargar GetAllStatus)
+	go forwardBusEvents(app, statusService)
+
+	// Icono de bandeja con el estado agregado de los sitios
+	setupSystemTray(app, window, statusService)
+
+	err := app.Run()
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// forwardBusEvents reenvía el bus interno a la UI como eventos de Wails
+func forwardBusEvents(app *application.App, statusService *StatusPageService) {
+	events, unsubscribe := statusService.events.subscribe()
+	defer unsubscribe()
+
+	for event := range events {
+		switch event.Type {
+		case BusEventCheck:
+			app.EmitEvent(eventStatusCheck, event)
+		case BusEventTransition:
+			app.EmitEvent(eventStatusTransition, event)
+		}
+	}
+}
+
+// promptSaveFile muestra el diálogo de guardado; devuelve "" si se cancela
+func promptSaveFile(filename, filterName, pattern string) (string, error) {
+	return application.SaveFileDialog().
+		SetFilename(filename).
+		AddFilter(filterName, pattern).
+		CanCreateDirectories(true).
+		PromptForSingleSelection()
+}
@@ -0,0 +1,20 @@
+//go:build headless
+
+package main
+
+import (
+	"fmt"
+	"log"
+)
+
+// Compilado con -tags headless: sin Wails ni dependencias gráficas
+const guiAvailable = false
+
+func runGUI(statusService *StatusPageService) {
+	log.Fatal("Este binario se compiló sin interfaz gráfica (-tags headless); use -headless")
+}
+
+// promptSaveFile no está disponible sin interfaz gráfica (use la API o la línea de comandos)
+func promptSaveFile(filename, filterName, pattern string) (string, error) {
+	return "", fmt.Errorf("diálogo de guardado no disponible sin interfaz gráfica")
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// runHeadless ejecuta el monitoreo, el almacenamiento y las notificaciones
+// sin interfaz gráfica hasta recibir SIGINT o SIGTERM
+func runHeadless(statusService *StatusPageService) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	log.Println("Modo sin interfaz gráfica")
+	statusService.Start(ctx)
+
+	<-ctx.Done()
+	stop()
+	log.Println("Señal recibida, deteniendo el servicio...")
+
+	if err := statusService.OnShutdown(); err != nil {
+		log.Printf("Error cerrando la base de datos: %v", err)
+	}
+}
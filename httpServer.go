@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+)
+
+// Configuración del servidor HTTP embebido (API REST)
+type HTTPServerConfig struct {
+	Enabled bool   `json:"enabled"`
+	Address string `json:"address,omitempty"` // por defecto 127.0.0.1:8420
+}
+
+const defaultHTTPAddress = "127.0.0.1:8420"
+
+func (c HTTPServerConfig) address() string {
+	if c.Address == "" {
+		return defaultHTTPAddress
+	}
+	return c.Address
+}
+
+// httpHandler construye el enrutador con todas las rutas HTTP. La página de estado,
+// los badges y los feeds son públicos; la API y las métricas requieren token.
+func (s *StatusPageService) httpHandler() http.Handler {
+	mux := http.NewServeMux()
+	s.registerAPIRoutes(mux)
+	mux.HandleFunc("/{$}", s.handleStatusPage)
+	mux.HandleFunc("/status", s.handleStatusPage)
+	mux.HandleFunc("/metrics", s.authorize(ScopeRead, ScopeRead, s.handleMetrics))
+	mux.HandleFunc("/badge/{site}/{kind}", s.handleBadge)
+	mux.HandleFunc("/feeds/{format}", s.handleFeed)
+	mux.HandleFunc("/feeds/groups/{group}/{format}", s.handleFeed)
+	return mux
+}
+
+// startHTTPServer inicia el servidor HTTP si está habilitado en la configuración
+func (s *StatusPageService) startHTTPServer() {
+	config := s.currentConfig()
+	if !config.HTTP.Enabled {
+		return
+	}
+
+	s.httpServer = &http.Server{
+		Addr:              config.HTTP.address(),
+		Handler:           s.httpHandler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		log.Printf("Servidor HTTP escuchando en http://%s", s.httpServer.Addr)
+		if err := s.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("Error en el servidor HTTP: %v", err)
+		}
+	}()
+}
+
+// stopHTTPServer detiene el servidor HTTP esperando las peticiones en curso
+func (s *StatusPageService) stopHTTPServer(ctx context.Context) {
+	if s.httpServer == nil {
+		return
+	}
+	if err := s.httpServer.Shutdown(ctx); err != nil {
+		log.Printf("Error deteniendo el servidor HTTP: %v", err)
+	}
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"database/sql"
+	"log"
+	"time"
+)
+
+// Incidente: periodo en que un sitio estuvo caído
+type Incident struct {
+	ID           int64      `json:"id"`
+	SiteName     string     `json:"siteName"`
+	StartedAt    time.Time  `json:"startedAt"`
+	ResolvedAt   *time.Time `json:"resolvedAt,omitempty"`
+	ErrorMessage string     `json:"errorMessage,omitempty"`
+
+	AcknowledgedAt *time.Time `json:"acknowledgedAt,omitempty"`
+	EscalatedAt    *time.Time `json:"escalatedAt,omitempty"`
+	LastReminderAt *time.Time `json:"lastReminderAt,omitempty"`
+}
+
+// Duration devuelve la duración del incidente; si sigue abierto se calcula hasta ahora
+func (i Incident) Duration() time.Duration {
+	end := time.Now()
+	if i.ResolvedAt != nil {
+		end = *i.ResolvedAt
+	}
+	return end.Sub(i.StartedAt).Truncate(time.Second)
+}
+
+func (i Incident) IsOpen() bool {
+	return i.ResolvedAt == nil
+}
+
+func (s *StatusPageService) initIncidentsDB() error {
+	createTableSQL := `
+	CREATE TABLE IF NOT EXISTS incidents (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		site_name TEXT NOT NULL,
+		started_at DATETIME NOT NULL,
+		resolved_at DATETIME,
+		error_message TEXT
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_incidents_site_name ON incidents(site_name);
+	CREATE INDEX IF NOT EXISTS idx_incidents_started_at ON incidents(started_at);
+	`
+
+	if _, err := s.db.Exec(createTableSQL); err != nil {
+		return err
+	}
+
+	// Columnas de reconocimiento y escalado
+	for _, column := range []string{"acknowledged_at", "escalated_at", "last_reminder_at"} {
+		if err := s.addColumnIfMissing("incidents", column, "DATETIME"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const incidentColumns = `id, site_name, started_at, resolved_at, error_message, acknowledged_at, escalated_at, last_reminder_at`
+
+func nullTimePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+func scanIncident(row interface{ Scan(...any) error }) (Incident, error) {
+	var incident Incident
+	var resolvedAt, acknowledgedAt, escalatedAt, lastReminderAt sql.NullTime
+	var errorMessage sql.NullString
+
+	err := row.Scan(&incident.ID, &incident.SiteName, &incident.StartedAt, &resolvedAt, &errorMessage,
+		&acknowledgedAt, &escalatedAt, &lastReminderAt)
+	if err != nil {
+		return incident, err
+	}
+
+	incident.ResolvedAt = nullTimePtr(resolvedAt)
+	incident.ErrorMessage = errorMessage.String
+	incident.AcknowledgedAt = nullTimePtr(acknowledgedAt)
+	incident.EscalatedAt = nullTimePtr(escalatedAt)
+	incident.LastReminderAt = nullTimePtr(lastReminderAt)
+	return incident, nil
+}
+
+// openIncident devuelve el incidente abierto del sitio, si existe
+func (s *StatusPageService) openIncident(siteName string) (*Incident, error) {
+	row := s.db.QueryRow(`SELECT `+incidentColumns+` FROM incidents
+	WHERE site_name = ? AND resolved_at IS NULL
+	ORDER BY started_at DESC
+	LIMIT 1`, siteName)
+
+	incident, err := scanIncident(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &incident, nil
+}
+
+// incidentByID devuelve un incidente por su ID
+func (s *StatusPageService) incidentByID(id int64) (*Incident, error) {
+	incident, err := scanIncident(s.db.QueryRow(`SELECT `+incidentColumns+` FROM incidents WHERE id = ?`, id))
+	if err != nil {
+		return nil, err
+	}
+	return &incident, nil
+}
+
+func (s *StatusPageService) createIncident(siteName string, startedAt time.Time, errorMsg string) (*Incident, error) {
+	result, err := s.db.Exec(`INSERT INTO incidents (site_name, started_at, error_message) VALUES (?, ?, ?)`,
+		siteName, startedAt.UTC(), errorMsg)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Incident{
+		ID:           id,
+		SiteName:     siteName,
+		StartedAt:    startedAt.UTC(),
+		ErrorMessage: errorMsg,
+	}, nil
+}
+
+func (s *StatusPageService) resolveIncident(incident *Incident, resolvedAt time.Time) error {
+	resolvedAt = resolvedAt.UTC()
+	_, err := s.db.Exec(`UPDATE incidents SET resolved_at = ? WHERE id = ?`, resolvedAt, incident.ID)
+	if err != nil {
+		return err
+	}
+	incident.ResolvedAt = &resolvedAt
+	return nil
+}
+
+// GetIncidents devuelve los últimos incidentes; si siteName está vacío se incluyen todos los sitios
+func (s *StatusPageService) GetIncidents(siteName string, limit int) ([]Incident, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+
+	query := `SELECT ` + incidentColumns + ` FROM incidents`
+	args := []any{}
+	if siteName != "" {
+		query += ` WHERE site_name = ?`
+		args = append(args, siteName)
+	}
+	query += ` ORDER BY started_at DESC LIMIT ?`
+	args = append(args, limit)
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var incidents []Incident
+	for rows.Next() {
+		incident, err := scanIncident(rows)
+		if err != nil {
+			log.Printf("Error leyendo incidente: %v", err)
+			continue
+		}
+		incidents = append(incidents, incident)
+	}
+
+	return incidents, rows.Err()
+}
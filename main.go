@@ -1,81 +1,51 @@
 package main
 
 import (
-	"context"
-	"embed"
-	_ "embed"
+	"flag"
+	"fmt"
 	"log"
-	"runtime"
-
-	"github.com/wailsapp/wails/v3/pkg/application"
-	"github.com/wailsapp/wails/v3/pkg/icons"
+	"os"
 )
 
-//go:embed all:frontend/dist
-var assets embed.FS
-
 func main() {
-	// Crear el servicio de status page
-	statusService := NewStatusPageService()
-
-	app := application.New(application.Options{
-		Name:        "StatusPage Monitor",
-		Description: "Monitor de estado de sitios web",
-		Services: []application.Service{
-			application.NewService(statusService),
-		},
-		Assets: application.AssetOptions{
-			Handler: application.AssetFileServerFS(assets),
-		},
-		Mac: application.MacOptions{
-			ActivationPolicy: application.ActivationPolicyAccessory,
-		},
-	})
-
-	// Iniciar el servicio de monitoreo
-	statusService.Start(context.Background())
-
-	window := app.NewWebviewWindowWithOptions(application.WebviewWindowOptions{
-		Title:       "StatusPage Monitor",
-		Width:       1100,
-		Height:      700,
-		Frameless:   true,
-		AlwaysOnTop: true,
-		Hidden:      true,
-		Windows: application.WindowsWindow{
-			HiddenOnTaskbar: true,
-		},
-		BackgroundColour: application.NewRGB(248, 249, 250),
-		URL:              "/",
-	})
-
-	systemTray := app.NewSystemTray()
+	// Subcomandos de línea de comandos (sites, check, status, ...)
+	if len(os.Args) > 1 {
+		if _, ok := cliCommands[os.Args[1]]; ok {
+			os.Exit(runCLI(os.Args[1], os.Args[2:]))
+		}
+	}
 
-	// Support for template icons on macOS
-	if runtime.GOOS == "darwin" {
-		systemTray.SetTemplateIcon(icons.SystrayMacTemplate)
-	} else {
-		// Support for light/dark mode icons
-		systemTray.SetDarkModeIcon(icons.SystrayDark)
-		systemTray.SetIcon(icons.SystrayLight)
+	headless := flag.Bool("headless", !guiAvailable, "ejecutar sin interfaz gráfica (servidor)")
+	httpAddr := flag.String("http", "", "habilitar la API REST en la dirección indicada (ej. 127.0.0.1:8420)")
+	createToken := flag.String("create-token", "", "crear un token de API con el nombre indicado y salir")
+	tokenScope := flag.String("token-scope", ScopeRead, "alcance del token creado con -create-token (read, write, admin)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Uso: %s [opciones] | <subcomando> [argumentos]\n\nOpciones:\n", os.Args[0])
+		flag.PrintDefaults()
+		fmt.Fprintln(flag.CommandLine.Output())
+		cliUsage(flag.CommandLine.Output())
 	}
+	flag.Parse()
 
-	// Support for menu
-	myMenu := app.NewMenu()
-	myMenu.Add("Mostrar StatusPage").OnClick(func(_ *application.Context) {
-		window.Show()
-		window.Focus()
-	})
-	myMenu.AddSeparator()
-	myMenu.Add("Salir").OnClick(func(_ *application.Context) {
-		app.Quit()
-	})
-	systemTray.SetMenu(myMenu)
+	// Crear el servicio de status page
+	statusService := NewStatusPageService()
 
-	systemTray.AttachWindow(window).WindowOffset(5)
+	if *createToken != "" {
+		token, err := statusService.CreateAPIToken(*createToken, *tokenScope)
+		if err != nil {
+			log.Fatal("Error creando token de API: ", err)
+		}
+		fmt.Println(token.Token)
+		statusService.db.Close()
+		return
+	}
+	if *httpAddr != "" {
+		statusService.config.HTTP = HTTPServerConfig{Enabled: true, Address: *httpAddr}
+	}
 
-	err := app.Run()
-	if err != nil {
-		log.Fatal(err)
+	if *headless {
+		runHeadless(statusService)
+		return
 	}
+	runGUI(statusService)
 }
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Ventana de mantenimiento programado. Si Sites y Groups están vacíos afecta a todos los sitios.
+type Maintenance struct {
+	ID          int64     `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description,omitempty"`
+	Sites       []string  `json:"sites,omitempty"`
+	Groups      []string  `json:"groups,omitempty"`
+	StartsAt    time.Time `json:"startsAt"`
+	EndsAt      time.Time `json:"endsAt"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// Status devuelve "scheduled", "in_progress" o "completed" según la hora actual
+func (m Maintenance) Status() string {
+	now := time.Now()
+	switch {
+	case now.Before(m.StartsAt):
+		return "scheduled"
+	case now.Before(m.EndsAt):
+		return "in_progress"
+	default:
+		return "completed"
+	}
+}
+
+// Affects indica si el mantenimiento incluye al sitio
+func (m Maintenance) Affects(site Site) bool {
+	return scopeIncludes(m.Sites, m.Groups, site)
+}
+
+// scopeIncludes indica si el sitio está en la lista de sitios o de grupos;
+// si ambas están vacías incluye a todos los sitios
+func scopeIncludes(sites, groups []string, site Site) bool {
+	if len(sites) == 0 && len(groups) == 0 {
+		return true
+	}
+	for _, name := range sites {
+		if name == site.Name {
+			return true
+		}
+	}
+	for _, group := range groups {
+		if site.Group != "" && group == site.Group {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *StatusPageService) initMaintenanceDB() error {
+	createTableSQL := `
+	CREATE TABLE IF NOT EXISTS maintenance_windows (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		title TEXT NOT NULL,
+		description TEXT,
+		site_names TEXT,
+		group_names TEXT,
+		starts_at DATETIME NOT NULL,
+		ends_at DATETIME NOT NULL,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_maintenance_windows_ends_at ON maintenance_windows(ends_at);
+	`
+
+	_, err := s.db.Exec(createTableSQL)
+	return err
+}
+
+// ScheduleMaintenance programa una ventana de mantenimiento
+func (s *StatusPageService) ScheduleMaintenance(m Maintenance) (*Maintenance, error) {
+	m.Title = strings.TrimSpace(m.Title)
+	if m.Title == "" {
+		return nil, fmt.Errorf("el mantenimiento debe tener un título")
+	}
+	if !m.EndsAt.After(m.StartsAt) {
+		return nil, fmt.Errorf("el fin del mantenimiento debe ser posterior al inicio")
+	}
+	for _, name := range m.Sites {
+		if _, ok := s.findSite(name); !ok {
+			return nil, fmt.Errorf("sitio '%s' no encontrado", name)
+		}
+	}
+
+	sites, err := json.Marshal(m.Sites)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := json.Marshal(m.Groups)
+	if err != nil {
+		return nil, err
+	}
+
+	m.StartsAt, m.EndsAt = m.StartsAt.UTC(), m.EndsAt.UTC()
+	result, err := s.db.Exec(`INSERT INTO maintenance_windows (title, description, site_names, group_names, starts_at, ends_at)
+	VALUES (?, ?, ?, ?, ?, ?)`, m.Title, m.Description, string(sites), string(groups), m.StartsAt, m.EndsAt)
+	if err != nil {
+		return nil, err
+	}
+
+	m.ID, err = result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	m.CreatedAt = time.Now().UTC()
+	return &m, nil
+}
+
+// CancelMaintenance elimina una ventana de mantenimiento
+func (s *StatusPageService) CancelMaintenance(id int64) error {
+	result, err := s.db.Exec(`DELETE FROM maintenance_windows WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("mantenimiento %d %w", id, errNotFound)
+	}
+	return nil
+}
+
+// GetMaintenances devuelve las ventanas de mantenimiento en curso y programadas;
+// con includePast también las ya terminadas
+func (s *StatusPageService) GetMaintenances(includePast bool) ([]Maintenance, error) {
+	query := `SELECT id, title, description, site_names, group_names, starts_at, ends_at, created_at FROM maintenance_windows`
+	args := []any{}
+	if !includePast {
+		query += ` WHERE ends_at > ?`
+		args = append(args, time.Now().UTC())
+	}
+	query += ` ORDER BY starts_at`
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var maintenances []Maintenance
+	for rows.Next() {
+		var m Maintenance
+		var description, sites, groups sql.NullString
+		if err := rows.Scan(&m.ID, &m.Title, &description, &sites, &groups, &m.StartsAt, &m.EndsAt, &m.CreatedAt); err != nil {
+			return nil, err
+		}
+		m.Description = description.String
+		if sites.Valid {
+			json.Unmarshal([]byte(sites.String), &m.Sites)
+		}
+		if groups.Valid {
+			json.Unmarshal([]byte(groups.String), &m.Groups)
+		}
+		maintenances = append(maintenances, m)
+	}
+
+	return maintenances, rows.Err()
+}
@@ -0,0 +1,265 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Estados de un incidente publicado manualmente
+const (
+	IncidentInvestigating = "investigating"
+	IncidentIdentified    = "identified"
+	IncidentMonitoring    = "monitoring"
+	IncidentResolved      = "resolved"
+)
+
+var incidentStatusLabels = map[string]string{
+	IncidentInvestigating: "Investigando",
+	IncidentIdentified:    "Causa identificada",
+	IncidentMonitoring:    "En observación",
+	IncidentResolved:      "Resuelto",
+}
+
+// Incidente publicado manualmente (ej. degradación que los checks no detectan).
+// Si Sites y Groups están vacíos afecta a todos los sitios.
+type ManualIncident struct {
+	ID         int64            `json:"id"`
+	Title      string           `json:"title"`
+	Status     string           `json:"status"`
+	Sites      []string         `json:"sites,omitempty"`
+	Groups     []string         `json:"groups,omitempty"`
+	CreatedAt  time.Time        `json:"createdAt"`
+	ResolvedAt *time.Time       `json:"resolvedAt,omitempty"`
+	Updates    []IncidentUpdate `json:"updates"`
+}
+
+// Actualización publicada de un incidente manual
+type IncidentUpdate struct {
+	ID        int64     `json:"id"`
+	Status    string    `json:"status"`
+	Message   string    `json:"message"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Affects indica si el incidente incluye al sitio
+func (i ManualIncident) Affects(site Site) bool {
+	return scopeIncludes(i.Sites, i.Groups, site)
+}
+
+func (s *StatusPageService) initManualIncidentsDB() error {
+	createTableSQL := `
+	CREATE TABLE IF NOT EXISTS manual_incidents (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		title TEXT NOT NULL,
+		status TEXT NOT NULL,
+		site_names TEXT,
+		group_names TEXT,
+		created_at DATETIME NOT NULL,
+		resolved_at DATETIME
+	);
+
+	CREATE TABLE IF NOT EXISTS incident_updates (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		incident_id INTEGER NOT NULL,
+		status TEXT NOT NULL,
+		message TEXT NOT NULL,
+		created_at DATETIME NOT NULL
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_incident_updates_incident_id ON incident_updates(incident_id);
+	`
+
+	_, err := s.db.Exec(createTableSQL)
+	return err
+}
+
+func validateIncidentStatus(status string) error {
+	if _, ok := incidentStatusLabels[status]; !ok {
+		return fmt.Errorf("estado de incidente desconocido '%s'", status)
+	}
+	return nil
+}
+
+// CreateManualIncident publica un incidente con su primera actualización
+func (s *StatusPageService) CreateManualIncident(title, status, message string, sites, groups []string) (*ManualIncident, error) {
+	title, message = strings.TrimSpace(title), strings.TrimSpace(message)
+	if title == "" || message == "" {
+		return nil, fmt.Errorf("el incidente debe tener título y mensaje")
+	}
+	if status == "" {
+		status = IncidentInvestigating
+	}
+	if err := validateIncidentStatus(status); err != nil {
+		return nil, err
+	}
+	for _, name := range sites {
+		if _, ok := s.findSite(name); !ok {
+			return nil, fmt.Errorf("sitio '%s' no encontrado", name)
+		}
+	}
+
+	siteNames, err := json.Marshal(sites)
+	if err != nil {
+		return nil, err
+	}
+	groupNames, err := json.Marshal(groups)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	var resolvedAt any
+	if status == IncidentResolved {
+		resolvedAt = now
+	}
+	// El incidente y su primera actualización se guardan juntos
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	result, err := tx.Exec(`INSERT INTO manual_incidents (title, status, site_names, group_names, created_at, resolved_at)
+	VALUES (?, ?, ?, ?, ?, ?)`, title, status, string(siteNames), string(groupNames), now, resolvedAt)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if _, err := tx.Exec(`INSERT INTO incident_updates (incident_id, status, message, created_at) VALUES (?, ?, ?, ?)`,
+		id, status, message, now); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return s.GetManualIncident(id)
+}
+
+// AddIncidentUpdate publica una actualización y cambia el estado del incidente
+func (s *StatusPageService) AddIncidentUpdate(id int64, status, message string) (*ManualIncident, error) {
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return nil, fmt.Errorf("la actualización debe tener un mensaje")
+	}
+	if err := validateIncidentStatus(status); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	var resolvedAt any
+	if status == IncidentResolved {
+		resolvedAt = now
+	}
+	// El cambio de estado y la actualización que lo explica se guardan juntos
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	result, err := tx.Exec(`UPDATE manual_incidents SET status = ?, resolved_at = ? WHERE id = ?`, status, resolvedAt, id)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		tx.Rollback()
+		return nil, fmt.Errorf("incidente %d no encontrado", id)
+	}
+
+	if _, err := tx.Exec(`INSERT INTO incident_updates (incident_id, status, message, created_at) VALUES (?, ?, ?, ?)`,
+		id, status, message, now); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return s.GetManualIncident(id)
+}
+
+// GetManualIncident devuelve un incidente manual con sus actualizaciones (más reciente primero)
+func (s *StatusPageService) GetManualIncident(id int64) (*ManualIncident, error) {
+	incidents, err := s.queryManualIncidents(`WHERE id = ?`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(incidents) == 0 {
+		return nil, fmt.Errorf("incidente %d no encontrado", id)
+	}
+	return &incidents[0], nil
+}
+
+// GetManualIncidents devuelve los últimos incidentes manuales con sus actualizaciones
+func (s *StatusPageService) GetManualIncidents(limit int) ([]ManualIncident, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	return s.queryManualIncidents(`ORDER BY created_at DESC LIMIT ?`, limit)
+}
+
+func (s *StatusPageService) queryManualIncidents(where string, args ...any) ([]ManualIncident, error) {
+	rows, err := s.db.Query(`SELECT id, title, status, site_names, group_names, created_at, resolved_at
+	FROM manual_incidents `+where, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var incidents []ManualIncident
+	for rows.Next() {
+		var incident ManualIncident
+		var sites, groups sql.NullString
+		var resolvedAt sql.NullTime
+		if err := rows.Scan(&incident.ID, &incident.Title, &incident.Status, &sites, &groups,
+			&incident.CreatedAt, &resolvedAt); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if sites.Valid {
+			json.Unmarshal([]byte(sites.String), &incident.Sites)
+		}
+		if groups.Valid {
+			json.Unmarshal([]byte(groups.String), &incident.Groups)
+		}
+		incident.ResolvedAt = nullTimePtr(resolvedAt)
+		incidents = append(incidents, incident)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range incidents {
+		updates, err := s.incidentUpdates(incidents[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		incidents[i].Updates = updates
+	}
+	return incidents, nil
+}
+
+func (s *StatusPageService) incidentUpdates(incidentID int64) ([]IncidentUpdate, error) {
+	rows, err := s.db.Query(`SELECT id, status, message, created_at FROM incident_updates
+	WHERE incident_id = ? ORDER BY created_at DESC, id DESC`, incidentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	updates := []IncidentUpdate{}
+	for rows.Next() {
+		var update IncidentUpdate
+		if err := rows.Scan(&update.ID, &update.Status, &update.Message, &update.CreatedAt); err != nil {
+			return nil, err
+		}
+		updates = append(updates, update)
+	}
+	return updates, rows.Err()
+}
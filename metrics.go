@@ -0,0 +1,328 @@
+package main
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Buckets (en segundos) de los histogramas
+var (
+	responseTimeBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+	dbWriteBuckets      = []float64{0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1}
+)
+
+type histogram struct {
+	buckets []float64
+	counts  []uint64 // acumulado por bucket, como espera Prometheus
+	sum     float64
+	count   uint64
+}
+
+func newHistogram(buckets []float64) *histogram {
+	return &histogram{buckets: buckets, counts: make([]uint64, len(buckets))}
+}
+
+func (h *histogram) observe(value float64) {
+	for i, bound := range h.buckets {
+		if value <= bound {
+			h.counts[i]++
+		}
+	}
+	h.sum += value
+	h.count++
+}
+
+// Métricas internas acumuladas en memoria desde el arranque
+type metricsRegistry struct {
+	mu sync.Mutex
+
+	checks       map[[2]string]uint64 // {sitio, estado}
+	failures     map[[2]string]uint64 // {sitio, clase de error}
+	responseTime map[string]*histogram
+	dbWrite      *histogram
+
+	schedulerLag   time.Duration
+	queueLength    int
+	checksInFlight int
+}
+
+func (m *metricsRegistry) init() {
+	if m.checks == nil {
+		m.checks = make(map[[2]string]uint64)
+		m.failures = make(map[[2]string]uint64)
+		m.responseTime = make(map[string]*histogram)
+		m.dbWrite = newHistogram(dbWriteBuckets)
+	}
+}
+
+// observeCheck registra el resultado de un check
+func (m *metricsRegistry) observeCheck(check StatusCheck) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.init()
+
+	m.checks[[2]string{check.SiteName, check.Status}]++
+	if class := checkErrorClass(check); class != "" {
+		m.failures[[2]string{check.SiteName, class}]++
+	}
+	if check.Status != "unreachable" {
+		h, ok := m.responseTime[check.SiteName]
+		if !ok {
+			h = newHistogram(responseTimeBuckets)
+			m.responseTime[check.SiteName] = h
+		}
+		h.observe(float64(check.ResponseTime) / 1000)
+	}
+}
+
+func (m *metricsRegistry) observeDBWrite(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.init()
+	m.dbWrite.observe(d.Seconds())
+}
+
+func (m *metricsRegistry) setSchedulerLag(d time.Duration) {
+	m.mu.Lock()
+	m.schedulerLag = d
+	m.mu.Unlock()
+}
+
+func (m *metricsRegistry) addQueue(delta int) {
+	m.mu.Lock()
+	m.queueLength += delta
+	m.mu.Unlock()
+}
+
+func (m *metricsRegistry) addInFlight(delta int) {
+	m.mu.Lock()
+	m.checksInFlight += delta
+	m.mu.Unlock()
+}
+
+// checkErrorClass clasifica el fallo de un check; devuelve "" si el sitio está arriba
+func checkErrorClass(check StatusCheck) string {
+	switch {
+	case check.Status == "up":
+		return ""
+	case check.Status == "unreachable":
+		return "dependency"
+	case check.StatusCode >= 500:
+		return "http_5xx"
+	case check.StatusCode >= 400:
+		return "http_4xx"
+	}
+
+	msg := strings.ToLower(check.ErrorMessage)
+	switch {
+	case strings.Contains(msg, "timeout") || strings.Contains(msg, "deadline exceeded"):
+		return "timeout"
+	case strings.Contains(msg, "no such host") || strings.Contains(msg, "server misbehaving"):
+		return "dns"
+	case strings.Contains(msg, "connection refused"):
+		return "connection_refused"
+	case strings.Contains(msg, "connection reset") || strings.Contains(msg, "eof"):
+		return "connection_reset"
+	case strings.Contains(msg, "x509") || strings.Contains(msg, "tls"):
+		return "tls"
+	}
+	return "other"
+}
+
+// metricsWriter escribe métricas en el formato de texto de Prometheus
+type metricsWriter struct {
+	buf bytes.Buffer
+}
+
+func (w *metricsWriter) header(name, kind, help string) {
+	fmt.Fprintf(&w.buf, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func (w *metricsWriter) sample(name string, value float64, labels ...string) {
+	w.buf.WriteString(name)
+	if len(labels) > 0 {
+		w.buf.WriteByte('{')
+		for i := 0; i+1 < len(labels); i += 2 {
+			if i > 0 {
+				w.buf.WriteByte(',')
+			}
+			fmt.Fprintf(&w.buf, `%s="%s"`, labels[i], escapeLabel(labels[i+1]))
+		}
+		w.buf.WriteByte('}')
+	}
+	w.buf.WriteByte(' ')
+	w.buf.WriteString(strconv.FormatFloat(value, 'g', -1, 64))
+	w.buf.WriteByte('\n')
+}
+
+func (w *metricsWriter) histogram(name string, h *histogram, labels ...string) {
+	for i, bound := range h.buckets {
+		w.sample(name+"_bucket", float64(h.counts[i]), append(labels, "le", strconv.FormatFloat(bound, 'g', -1, 64))...)
+	}
+	w.sample(name+"_bucket", float64(h.count), append(labels, "le", "+Inf")...)
+	w.sample(name+"_sum", h.sum, labels...)
+	w.sample(name+"_count", float64(h.count), labels...)
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabel(value string) string {
+	return labelEscaper.Replace(value)
+}
+
+func sortedKeys[V any](m map[[2]string]V) [][2]string {
+	keys := make([][2]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+	return keys
+}
+
+// writeSiteMetrics escribe los gauges por sitio a partir del último check guardado
+func (s *StatusPageService) writeSiteMetrics(w *metricsWriter) {
+	type siteMetrics struct {
+		site       Site
+		up         float64
+		response   float64
+		statusCode int
+		checkedAt  time.Time
+		certDays   *float64
+	}
+
+	// Un solo recorrido de status_checks: id es AUTOINCREMENT y checked_at se asigna
+	// al insertar, así que el id mayor de cada sitio es su último check
+	rows, err := s.db.Query(`
+	SELECT c.site_name, c.status, c.status_code, c.response_time, c.checked_at, cert.cert_expires_at
+	FROM (
+		SELECT site_name,
+			   MAX(id) AS check_id,
+			   MAX(CASE WHEN cert_expires_at IS NOT NULL THEN id END) AS cert_id
+		FROM status_checks
+		GROUP BY site_name
+	) latest
+	JOIN status_checks c ON c.id = latest.check_id
+	LEFT JOIN status_checks cert ON cert.id = latest.cert_id`)
+	if err != nil {
+		log.Printf("Error obteniendo métricas de los sitios: %v", err)
+		return
+	}
+	latest := make(map[string]siteMetrics)
+	for rows.Next() {
+		var m siteMetrics
+		var name, status string
+		var responseTime int64
+		var certExpiresAt sql.NullTime
+		if err := rows.Scan(&name, &status, &m.statusCode, &responseTime, &m.checkedAt, &certExpiresAt); err != nil {
+			log.Printf("Error leyendo métricas de %s: %v", name, err)
+			continue
+		}
+		if status == "up" {
+			m.up = 1
+		}
+		m.response = float64(responseTime) / 1000
+		if certExpiresAt.Valid {
+			days := time.Until(certExpiresAt.Time).Hours() / 24
+			m.certDays = &days
+		}
+		latest[name] = m
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		log.Printf("Error obteniendo métricas de los sitios: %v", err)
+		return
+	}
+
+	// Se conserva el orden de config.json y se omiten los sitios sin checks
+	var sites []siteMetrics
+	for _, site := range s.currentConfig().Sites {
+		if m, ok := latest[site.Name]; ok {
+			m.site = site
+			sites = append(sites, m)
+		}
+	}
+
+	w.header("statuspage_site_up", "gauge", "1 si el último check del sitio fue correcto")
+	for _, m := range sites {
+		w.sample("statuspage_site_up", m.up, "site", m.site.Name, "group", m.site.Group)
+	}
+	w.header("statuspage_site_response_time_seconds", "gauge", "Tiempo de respuesta del último check")
+	for _, m := range sites {
+		w.sample("statuspage_site_response_time_seconds", m.response, "site", m.site.Name)
+	}
+	w.header("statuspage_site_status_code", "gauge", "Código HTTP del último check (0 si no hubo respuesta)")
+	for _, m := range sites {
+		w.sample("statuspage_site_status_code", float64(m.statusCode), "site", m.site.Name)
+	}
+	w.header("statuspage_site_last_check_timestamp_seconds", "gauge", "Hora del último check (epoch)")
+	for _, m := range sites {
+		w.sample("statuspage_site_last_check_timestamp_seconds", float64(m.checkedAt.Unix()), "site", m.site.Name)
+	}
+	w.header("statuspage_site_cert_expiry_days", "gauge", "Días hasta la expiración del certificado TLS")
+	for _, m := range sites {
+		if m.certDays != nil {
+			w.sample("statuspage_site_cert_expiry_days", *m.certDays, "site", m.site.Name)
+		}
+	}
+}
+
+// handleMetrics expone las métricas en formato de texto de Prometheus
+func (s *StatusPageService) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "método no permitido", http.StatusMethodNotAllowed)
+		return
+	}
+
+	mw := &metricsWriter{}
+	s.writeSiteMetrics(mw)
+
+	m := &s.metrics
+	m.mu.Lock()
+	m.init()
+
+	mw.header("statuspage_checks_total", "counter", "Checks realizados por sitio y estado")
+	for _, key := range sortedKeys(m.checks) {
+		mw.sample("statuspage_checks_total", float64(m.checks[key]), "site", key[0], "status", key[1])
+	}
+	mw.header("statuspage_check_failures_total", "counter", "Checks fallidos por sitio y clase de error")
+	for _, key := range sortedKeys(m.failures) {
+		mw.sample("statuspage_check_failures_total", float64(m.failures[key]), "site", key[0], "class", key[1])
+	}
+
+	mw.header("statuspage_check_response_seconds", "histogram", "Tiempo de respuesta de los checks")
+	sites := make([]string, 0, len(m.responseTime))
+	for site := range m.responseTime {
+		sites = append(sites, site)
+	}
+	sort.Strings(sites)
+	for _, site := range sites {
+		mw.histogram("statuspage_check_response_seconds", m.responseTime[site], "site", site)
+	}
+
+	mw.header("statuspage_scheduler_lag_seconds", "gauge", "Retraso del último ciclo de checks respecto a lo programado")
+	mw.sample("statuspage_scheduler_lag_seconds", m.schedulerLag.Seconds())
+	mw.header("statuspage_check_queue_length", "gauge", "Checks pendientes de iniciar en el ciclo actual")
+	mw.sample("statuspage_check_queue_length", float64(m.queueLength))
+	mw.header("statuspage_checks_in_progress", "gauge", "Checks en ejecución")
+	mw.sample("statuspage_checks_in_progress", float64(m.checksInFlight))
+	mw.header("statuspage_db_write_seconds", "histogram", "Latencia de escritura de checks en la base de datos")
+	mw.histogram("statuspage_db_write_seconds", m.dbWrite)
+	m.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.Write(mw.buf.Bytes())
+}
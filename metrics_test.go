@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// scrapeMetrics llama a /metrics y devuelve las muestras indexadas por nombre y etiquetas
+func scrapeMetrics(t *testing.T, s *StatusPageService) (map[string]float64, []string) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	s.handleMetrics(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "text/plain; version=0.0.4; charset=utf-8" {
+		t.Fatalf("respuesta = %d %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+
+	samples := make(map[string]float64)
+	var lines []string
+	typed := make(map[string]bool)
+	scanner := bufio.NewScanner(rec.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		lines = append(lines, line)
+		if strings.HasPrefix(line, "# TYPE ") {
+			typed[strings.Fields(line)[2]] = true
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		i := strings.LastIndexByte(line, ' ')
+		value, err := strconv.ParseFloat(line[i+1:], 64)
+		if err != nil {
+			t.Fatalf("valor inválido en %q", line)
+		}
+		// Cada muestra va después del # TYPE de su familia
+		family := strings.TrimSuffix(strings.TrimSuffix(strings.TrimSuffix(line[:strings.IndexAny(line, "{ ")], "_bucket"), "_sum"), "_count")
+		if !typed[family] {
+			t.Errorf("muestra sin # TYPE: %q", line)
+		}
+		samples[line[:i]] = value
+	}
+	return samples, lines
+}
+
+func TestMetricsExposition(t *testing.T) {
+	s := newTestService(t)
+	const name = "api \"v2\" \\ pagos\nnuevo"
+	s.config.Sites = []Site{{Name: name, URL: "https://api.example.com", Method: "GET", Timeout: 5, Group: "Pagos"}}
+
+	// El certificado se toma del último check que lo tiene, aunque no sea el más reciente
+	expires := time.Now().Add(30*24*time.Hour + time.Hour)
+	insertCheck(t, s, name, "up", 100, 2*time.Minute, &expires)
+	insertCheck(t, s, name, "down", 250, time.Minute, nil)
+
+	for _, ms := range []int64{30, 300, 20000} {
+		s.metrics.observeCheck(StatusCheck{SiteName: name, Status: "up", ResponseTime: ms})
+	}
+	// Un fallo sin respuesta también se observa en el histograma, con 0 s
+	s.metrics.observeCheck(StatusCheck{SiteName: name, Status: "down", StatusCode: 503})
+
+	samples, lines := scrapeMetrics(t, s)
+	const site = `site="api \"v2\" \\ pagos\nnuevo"`
+	for _, want := range []string{
+		"# HELP statuspage_site_up 1 si el último check del sitio fue correcto",
+		"# TYPE statuspage_site_up gauge",
+		"# TYPE statuspage_check_response_seconds histogram",
+		"# TYPE statuspage_checks_total counter",
+	} {
+		if !strings.Contains(strings.Join(lines, "\n"), want) {
+			t.Errorf("falta %q", want)
+		}
+	}
+
+	expected := map[string]float64{
+		"statuspage_site_up{" + site + `,group="Pagos"}`:                   0,
+		"statuspage_site_response_time_seconds{" + site + "}":              0.25,
+		"statuspage_site_status_code{" + site + "}":                        200,
+		"statuspage_checks_total{" + site + `,status="up"}`:                3,
+		"statuspage_checks_total{" + site + `,status="down"}`:              1,
+		"statuspage_check_failures_total{" + site + `,class="http_5xx"}`:   1,
+		"statuspage_check_response_seconds_bucket{" + site + `,le="0.05"}`: 2,
+		"statuspage_check_response_seconds_bucket{" + site + `,le="0.25"}`: 2,
+		"statuspage_check_response_seconds_bucket{" + site + `,le="0.5"}`:  3,
+		"statuspage_check_response_seconds_bucket{" + site + `,le="10"}`:   3,
+		"statuspage_check_response_seconds_bucket{" + site + `,le="+Inf"}`: 4,
+		"statuspage_check_response_seconds_count{" + site + "}":            4,
+		"statuspage_check_response_seconds_sum{" + site + "}":              20.33,
+		`statuspage_db_write_seconds_bucket{le="+Inf"}`:                    0,
+		"statuspage_checks_in_progress":                                    0,
+	}
+	for key, want := range expected {
+		got, ok := samples[key]
+		if !ok {
+			t.Errorf("falta la muestra %s", key)
+		} else if got != want {
+			t.Errorf("%s = %v, se esperaba %v", key, got, want)
+		}
+	}
+	if days := samples["statuspage_site_cert_expiry_days{"+site+"}"]; days < 30 || days > 31 {
+		t.Errorf("días hasta la expiración = %v", days)
+	}
+
+	// Los buckets son acumulados: nunca decrecen y el último coincide con _count
+	var previous float64
+	for _, line := range lines {
+		if !strings.HasPrefix(line, "statuspage_check_response_seconds_bucket{") {
+			continue
+		}
+		value, _ := strconv.ParseFloat(line[strings.LastIndexByte(line, ' ')+1:], 64)
+		if value < previous {
+			t.Errorf("bucket decreciente: %q", line)
+		}
+		previous = value
+	}
+}
+
+// Un sitio sin checks no expone gauges
+func TestMetricsSiteWithoutChecks(t *testing.T) {
+	s := newTestService(t)
+	s.config.Sites = []Site{{Name: "nuevo", URL: "https://example.com", Method: "GET", Timeout: 5}}
+
+	samples, _ := scrapeMetrics(t, s)
+	for key := range samples {
+		if strings.Contains(key, `site="nuevo"`) {
+			t.Errorf("muestra inesperada %s", key)
+		}
+	}
+}
@@ -0,0 +1,301 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+)
+
+// Severidades de sitio
+const (
+	SeverityCritical = "critical"
+	SeverityNormal   = "normal"
+	SeverityLow      = "low"
+)
+
+// Acciones para eventos fuera del horario del canal
+const (
+	ScheduleDrop     = "drop"     // descartar
+	ScheduleDelay    = "delay"    // retrasar hasta que abra la ventana
+	ScheduleFallback = "fallback" // enviar por el canal alternativo
+)
+
+// Horario en que un canal envía notificaciones. Si End es menor que Start
+// la ventana cruza la medianoche (ej. 22:00-06:00).
+type NotificationSchedule struct {
+	Timezone string `json:"timezone,omitempty"` // zona IANA; vacío = hora local
+	Weekdays []int  `json:"weekdays,omitempty"` // 0 = domingo ... 6 = sábado; vacío = todos
+	Start    string `json:"start,omitempty"`    // "HH:MM"; vacío = todo el día
+	End      string `json:"end,omitempty"`      // "HH:MM"
+
+	// Severidades de sitio que se envían siempre, incluso fuera de horario
+	AlwaysSeverities []string `json:"alwaysSeverities,omitempty"`
+	OutsideAction    string   `json:"outsideAction,omitempty"` // "drop" (por defecto), "delay", "fallback"
+	FallbackChannel  string   `json:"fallbackChannel,omitempty"`
+}
+
+// siteSeverity devuelve la severidad del sitio o la severidad por defecto
+func siteSeverity(site Site) string {
+	if site.Severity == "" {
+		return SeverityNormal
+	}
+	return site.Severity
+}
+
+func parseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("hora inválida '%s' (formato HH:MM)", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func (sc NotificationSchedule) location() *time.Location {
+	if sc.Timezone == "" {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(sc.Timezone)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
+// validate verifica la zona horaria, las horas y la acción fuera de horario
+// del canal channelName
+func (sc NotificationSchedule) validate(channelName string, channels []NotificationChannel) error {
+	if sc.Timezone != "" {
+		if _, err := time.LoadLocation(sc.Timezone); err != nil {
+			return fmt.Errorf("zona horaria inválida '%s'", sc.Timezone)
+		}
+	}
+	if (sc.Start == "") != (sc.End == "") {
+		return fmt.Errorf("el horario debe indicar inicio y fin")
+	}
+	if sc.Start != "" {
+		if _, err := parseClock(sc.Start); err != nil {
+			return err
+		}
+		if _, err := parseClock(sc.End); err != nil {
+			return err
+		}
+	}
+	for _, day := range sc.Weekdays {
+		if day < 0 || day > 6 {
+			return fmt.Errorf("día de la semana inválido %d (0-6)", day)
+		}
+	}
+
+	switch sc.OutsideAction {
+	case "", ScheduleDrop, ScheduleDelay:
+	case ScheduleFallback:
+		if sc.FallbackChannel == channelName {
+			return fmt.Errorf("el canal alternativo no puede ser el mismo canal")
+		}
+		for _, channel := range channels {
+			if channel.Name == sc.FallbackChannel {
+				return nil
+			}
+		}
+		return fmt.Errorf("canal alternativo '%s' no encontrado", sc.FallbackChannel)
+	default:
+		return fmt.Errorf("acción fuera de horario desconocida '%s'", sc.OutsideAction)
+	}
+	return nil
+}
+
+func (sc NotificationSchedule) weekdayAllowed(day time.Weekday) bool {
+	if len(sc.Weekdays) == 0 {
+		return true
+	}
+	for _, d := range sc.Weekdays {
+		if time.Weekday(d) == day {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains indica si el instante cae dentro del horario
+func (sc NotificationSchedule) Contains(t time.Time) bool {
+	local := t.In(sc.location())
+	if sc.Start == "" {
+		return sc.weekdayAllowed(local.Weekday())
+	}
+
+	start, _ := parseClock(sc.Start)
+	end, _ := parseClock(sc.End)
+	minutes := local.Hour()*60 + local.Minute()
+
+	switch {
+	case start == end:
+		return sc.weekdayAllowed(local.Weekday())
+	case start < end:
+		return sc.weekdayAllowed(local.Weekday()) && minutes >= start && minutes < end
+	case minutes >= start:
+		return sc.weekdayAllowed(local.Weekday())
+	case minutes < end:
+		// Tramo posterior a medianoche: cuenta el día en que empezó la ventana
+		return sc.weekdayAllowed(local.AddDate(0, 0, -1).Weekday())
+	}
+	return false
+}
+
+// NextOpening devuelve el próximo instante en que abre la ventana (o t si ya está abierta)
+func (sc NotificationSchedule) NextOpening(t time.Time) time.Time {
+	if sc.Contains(t) {
+		return t
+	}
+
+	local := t.In(sc.location())
+	start := 0
+	if sc.Start != "" {
+		start, _ = parseClock(sc.Start)
+	}
+	for days := 0; days <= 7; days++ {
+		day := local.AddDate(0, 0, days)
+		candidate := time.Date(day.Year(), day.Month(), day.Day(), start/60, start%60, 0, 0, local.Location())
+		if candidate.After(t) && sc.weekdayAllowed(candidate.Weekday()) {
+			return candidate
+		}
+	}
+	return t
+}
+
+func (s *StatusPageService) initPendingNotificationsDB() error {
+	createTableSQL := `
+	CREATE TABLE IF NOT EXISTS pending_notifications (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		channel TEXT NOT NULL,
+		event TEXT NOT NULL,
+		deliver_at DATETIME NOT NULL,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_pending_notifications_deliver_at ON pending_notifications(deliver_at);
+	`
+
+	_, err := s.db.Exec(createTableSQL)
+	return err
+}
+
+// dispatch envía el evento al canal respetando su horario
+func (s *StatusPageService) dispatch(channel NotificationChannel, event NotificationEvent) {
+	s.dispatchAt(channel, event, time.Now(), 0)
+}
+
+func (s *StatusPageService) dispatchAt(channel NotificationChannel, event NotificationEvent, now time.Time, depth int) {
+	schedule := channel.Schedule
+	if schedule == nil || schedule.Contains(now) {
+		s.goTracked(func() { s.deliver(channel, event) })
+		return
+	}
+	for _, severity := range schedule.AlwaysSeverities {
+		if severity == siteSeverity(event.Site) {
+			s.goTracked(func() { s.deliver(channel, event) })
+			return
+		}
+	}
+
+	switch schedule.OutsideAction {
+	case ScheduleDelay:
+		deliverAt := schedule.NextOpening(now)
+		if err := s.queueNotification(channel.Name, event, deliverAt); err != nil {
+			log.Printf("Error retrasando notificación por '%s': %v", channel.Name, err)
+			return
+		}
+		log.Printf("Notificación por '%s' fuera de horario, se enviará el %s", channel.Name, deliverAt.Format("2006-01-02 15:04 MST"))
+
+	case ScheduleFallback:
+		fallback, ok := s.findNotificationChannel(schedule.FallbackChannel)
+		// depth evita bucles entre canales que se usan mutuamente como alternativa
+		if !ok || !fallback.Enabled || depth >= 3 {
+			log.Printf("Canal alternativo '%s' no disponible para '%s'", schedule.FallbackChannel, channel.Name)
+			return
+		}
+		s.dispatchAt(fallback, event, now, depth+1)
+
+	default:
+		s.saveNotificationDelivery(&NotificationDelivery{
+			Channel:     channel.Name,
+			ChannelType: channel.Type,
+			SiteName:    event.Site.Name,
+			EventType:   event.Type,
+			IncidentID:  event.Incident.ID,
+			Status:      "dropped",
+			Error:       "fuera del horario del canal",
+		})
+	}
+}
+
+func (s *StatusPageService) queueNotification(channelName string, event NotificationEvent, deliverAt time.Time) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`INSERT INTO pending_notifications (channel, event, deliver_at) VALUES (?, ?, ?)`,
+		channelName, string(data), deliverAt.UTC())
+	return err
+}
+
+// flushPendingNotifications envía las notificaciones retrasadas cuya ventana ya abrió
+func (s *StatusPageService) flushPendingNotifications() {
+	rows, err := s.db.Query(`SELECT id, channel, event FROM pending_notifications WHERE deliver_at <= ? ORDER BY id`,
+		time.Now().UTC())
+	if err != nil {
+		log.Printf("Error leyendo notificaciones pendientes: %v", err)
+		return
+	}
+
+	type pending struct {
+		id      int64
+		channel string
+		event   NotificationEvent
+	}
+	var due []pending
+	for rows.Next() {
+		var p pending
+		var data string
+		if err := rows.Scan(&p.id, &p.channel, &data); err != nil {
+			log.Printf("Error leyendo notificación pendiente: %v", err)
+			continue
+		}
+		if err := json.Unmarshal([]byte(data), &p.event); err != nil {
+			log.Printf("Error decodificando notificación pendiente %d: %v", p.id, err)
+		}
+		due = append(due, p)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		// Se vuelven a leer en la próxima verificación
+		log.Printf("Error leyendo notificaciones pendientes: %v", err)
+		return
+	}
+
+	for _, p := range due {
+		if _, err := s.db.Exec(`DELETE FROM pending_notifications WHERE id = ?`, p.id); err != nil {
+			log.Printf("Error eliminando notificación pendiente %d: %v", p.id, err)
+			continue
+		}
+		channel, ok := s.findNotificationChannel(p.channel)
+		if !ok || !channel.Enabled || p.event.Type == "" {
+			continue
+		}
+		s.goTracked(func() { s.deliver(channel, p.event) })
+	}
+}
+
+// SetSiteSeverity cambia la severidad del sitio ("critical", "normal", "low")
+func (s *StatusPageService) SetSiteSeverity(name, severity string) error {
+	switch severity {
+	case SeverityCritical, SeverityNormal, SeverityLow:
+	default:
+		return fmt.Errorf("severidad desconocida '%s'", severity)
+	}
+
+	return s.updateSite(name, func(c *Config, site *Site) error {
+		site.Severity = severity
+		return nil
+	})
+}
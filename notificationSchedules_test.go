@@ -0,0 +1,254 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScheduleContains(t *testing.T) {
+	utc := func(value string) time.Time {
+		t, err := time.Parse("2006-01-02 15:04", value)
+		if err != nil {
+			panic(err)
+		}
+		return t
+	}
+
+	// El 6 de mayo de 2024 es lunes; el 10 de marzo de 2024 empieza el horario de verano en Nueva York
+	overnightMonday := NotificationSchedule{Timezone: "UTC", Weekdays: []int{1}, Start: "22:00", End: "06:00"}
+	officeMadrid := NotificationSchedule{Timezone: "Europe/Madrid", Weekdays: []int{1, 2, 3, 4, 5}, Start: "09:00", End: "18:00"}
+	earlyNewYork := NotificationSchedule{Timezone: "America/New_York", Start: "01:00", End: "03:30"}
+	weekend := NotificationSchedule{Timezone: "UTC", Weekdays: []int{0, 6}}
+	allDay := NotificationSchedule{Timezone: "UTC", Start: "00:00", End: "00:00"}
+
+	tests := []struct {
+		name     string
+		schedule NotificationSchedule
+		at       time.Time
+		want     bool
+	}{
+		{"noche del lunes", overnightMonday, utc("2024-05-06 23:00"), true},
+		{"madrugada del martes cuenta como lunes", overnightMonday, utc("2024-05-07 03:00"), true},
+		{"madrugada del lunes cuenta como domingo", overnightMonday, utc("2024-05-06 03:00"), false},
+		{"noche del martes", overnightMonday, utc("2024-05-07 23:00"), false},
+		{"antes de abrir", overnightMonday, utc("2024-05-06 21:59"), false},
+		{"el fin es exclusivo", overnightMonday, utc("2024-05-07 06:00"), false},
+
+		{"oficina en hora de Madrid", officeMadrid, utc("2024-05-06 07:30"), true},
+		{"pasadas las 18:00 en Madrid", officeMadrid, utc("2024-05-06 16:30"), false},
+		{"antes de las 9:00 en Madrid", officeMadrid, utc("2024-05-06 06:30"), false},
+		{"sábado en Madrid", officeMadrid, utc("2024-05-11 10:00"), false},
+
+		{"antes del cambio de hora", earlyNewYork, utc("2024-03-10 06:59"), true},
+		{"después del cambio de hora", earlyNewYork, utc("2024-03-10 07:00"), true},
+		{"fin tras el cambio de hora", earlyNewYork, utc("2024-03-10 07:30"), false},
+
+		{"domingo", weekend, utc("2024-05-05 12:00"), true},
+		{"lunes", weekend, utc("2024-05-06 12:00"), false},
+		{"inicio igual al fin", allDay, utc("2024-05-06 12:00"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.schedule.Contains(tt.at); got != tt.want {
+				t.Errorf("Contains(%s) = %v", tt.at.In(tt.schedule.location()), got)
+			}
+		})
+	}
+}
+
+func TestScheduleNextOpening(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	monday := NotificationSchedule{Timezone: "UTC", Weekdays: []int{1}, Start: "09:00", End: "17:00"}
+	overnight := NotificationSchedule{Timezone: "UTC", Weekdays: []int{1}, Start: "22:00", End: "06:00"}
+	daily := NotificationSchedule{Timezone: "America/New_York", Start: "09:00", End: "17:00"}
+
+	tests := []struct {
+		name     string
+		schedule NotificationSchedule
+		from     time.Time
+		want     time.Time
+	}{
+		{"mismo día", monday, time.Date(2024, 5, 6, 8, 0, 0, 0, time.UTC), time.Date(2024, 5, 6, 9, 0, 0, 0, time.UTC)},
+		{"ya abierta", monday, time.Date(2024, 5, 6, 10, 0, 0, 0, time.UTC), time.Date(2024, 5, 6, 10, 0, 0, 0, time.UTC)},
+		{"lunes siguiente desde el martes", monday, time.Date(2024, 5, 7, 10, 0, 0, 0, time.UTC), time.Date(2024, 5, 13, 9, 0, 0, 0, time.UTC)},
+		{"una semana después", monday, time.Date(2024, 5, 6, 18, 0, 0, 0, time.UTC), time.Date(2024, 5, 13, 9, 0, 0, 0, time.UTC)},
+		{"ventana nocturna", overnight, time.Date(2024, 5, 6, 12, 0, 0, 0, time.UTC), time.Date(2024, 5, 6, 22, 0, 0, 0, time.UTC)},
+		{"tras el cambio de hora", daily, time.Date(2024, 3, 9, 20, 0, 0, 0, newYork), time.Date(2024, 3, 10, 13, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.schedule.NextOpening(tt.from); !got.Equal(tt.want) {
+				t.Errorf("NextOpening = %s, se esperaba %s", got.UTC(), tt.want.UTC())
+			}
+		})
+	}
+}
+
+func TestScheduleValidate(t *testing.T) {
+	channels := []NotificationChannel{{Name: "slack"}, {Name: "sms"}}
+	tests := []struct {
+		schedule NotificationSchedule
+		err      string
+	}{
+		{NotificationSchedule{Start: "09:00", End: "18:00", OutsideAction: ScheduleFallback, FallbackChannel: "sms"}, ""},
+		{NotificationSchedule{OutsideAction: ScheduleFallback, FallbackChannel: "slack"}, "mismo canal"},
+		{NotificationSchedule{OutsideAction: ScheduleFallback, FallbackChannel: "email"}, "no encontrado"},
+		{NotificationSchedule{Timezone: "Marte/Olympus"}, "zona horaria"},
+		{NotificationSchedule{Start: "09:00"}, "inicio y fin"},
+		{NotificationSchedule{Start: "25:00", End: "06:00"}, "hora inválida"},
+		{NotificationSchedule{Weekdays: []int{7}}, "día de la semana"},
+		{NotificationSchedule{OutsideAction: "ignorar"}, "desconocida"},
+	}
+
+	for _, tt := range tests {
+		err := tt.schedule.validate("slack", channels)
+		if tt.err == "" && err != nil || tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)) {
+			t.Errorf("%+v: error = %v, se esperaba %q", tt.schedule, err, tt.err)
+		}
+	}
+}
+
+// scheduleService configura los canales como webhooks hacia rec
+func scheduleService(t *testing.T, rec *webhookRecorder, channels ...NotificationChannel) *StatusPageService {
+	t.Helper()
+	s := newTestService(t)
+	for i := range channels {
+		channels[i].Type, channels[i].Enabled, channels[i].RoutedOnly = "webhook", true, true
+		channels[i].Webhook = &WebhookConfig{URL: rec.URL}
+	}
+	err := s.updateConfig(func(c *Config) error {
+		c.Notifications = channels
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// closedAtNoon está cerrado a las 12:00 UTC y abre a las 22:00
+var closedAtNoon = NotificationSchedule{Timezone: "UTC", Start: "22:00", End: "06:00"}
+
+func withFallback(schedule NotificationSchedule, fallback string) *NotificationSchedule {
+	schedule.OutsideAction, schedule.FallbackChannel = ScheduleFallback, fallback
+	return &schedule
+}
+
+func TestDispatchFallbackDepth(t *testing.T) {
+	noon := time.Date(2024, 5, 6, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		channels []NotificationChannel
+		sent     int
+	}{
+		{"tres saltos", []NotificationChannel{
+			{Name: "a", Schedule: withFallback(closedAtNoon, "b")},
+			{Name: "b", Schedule: withFallback(closedAtNoon, "c")},
+			{Name: "c", Schedule: withFallback(closedAtNoon, "d")},
+			{Name: "d"},
+		}, 1},
+		{"cuatro saltos superan el límite", []NotificationChannel{
+			{Name: "a", Schedule: withFallback(closedAtNoon, "b")},
+			{Name: "b", Schedule: withFallback(closedAtNoon, "c")},
+			{Name: "c", Schedule: withFallback(closedAtNoon, "d")},
+			{Name: "d", Schedule: withFallback(closedAtNoon, "e")},
+			{Name: "e"},
+		}, 0},
+		{"canales que se usan mutuamente", []NotificationChannel{
+			{Name: "a", Schedule: withFallback(closedAtNoon, "b")},
+			{Name: "b", Schedule: withFallback(closedAtNoon, "a")},
+		}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := newWebhookRecorder(t)
+			s := scheduleService(t, rec, tt.channels...)
+			channel, _ := s.findNotificationChannel("a")
+
+			s.dispatchAt(channel, testEvent(EventDown), noon, 0)
+			s.inFlight.Wait()
+			if sent := rec.take(); len(sent) != tt.sent {
+				t.Errorf("enviadas = %d, se esperaban %d", len(sent), tt.sent)
+			}
+		})
+	}
+}
+
+func TestDispatchOutsideSchedule(t *testing.T) {
+	noon := time.Date(2024, 5, 6, 12, 0, 0, 0, time.UTC)
+	delayed, critical := closedAtNoon, closedAtNoon
+	delayed.OutsideAction = ScheduleDelay
+	critical.AlwaysSeverities = []string{SeverityCritical}
+
+	rec := newWebhookRecorder(t)
+	s := scheduleService(t, rec,
+		NotificationChannel{Name: "descartar", Schedule: &closedAtNoon},
+		NotificationChannel{Name: "retrasar", Schedule: &delayed},
+		NotificationChannel{Name: "criticos", Schedule: &critical},
+	)
+	dispatch := func(name string, event NotificationEvent) {
+		channel, _ := s.findNotificationChannel(name)
+		s.dispatchAt(channel, event, noon, 0)
+		s.inFlight.Wait()
+	}
+
+	dispatch("descartar", testEvent(EventDown))
+	log, err := s.GetNotificationLog(10)
+	if err != nil || len(log) != 1 || log[0].Status != "dropped" || log[0].Channel != "descartar" {
+		t.Errorf("registro = %+v, %v", log, err)
+	}
+
+	criticalEvent := testEvent(EventDown)
+	criticalEvent.Site.Severity = SeverityCritical
+	dispatch("criticos", criticalEvent)
+	dispatch("criticos", testEvent(EventDown))
+	if sent := rec.take(); len(sent) != 1 {
+		t.Errorf("solo debe enviarse el evento crítico: %+v", sent)
+	}
+
+	// Se guarda para la apertura de la ventana y se envía al vaciar la cola
+	dispatch("retrasar", testEvent(EventDown))
+	var deliverAt time.Time
+	if err := s.db.QueryRow(`SELECT deliver_at FROM pending_notifications WHERE channel = 'retrasar'`).Scan(&deliverAt); err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2024, 5, 6, 22, 0, 0, 0, time.UTC); !deliverAt.Equal(want) {
+		t.Errorf("deliver_at = %s, se esperaba %s", deliverAt, want)
+	}
+
+	// Un canal eliminado descarta su fila; la de "retrasar" espera a deliver_at
+	if _, err := s.db.Exec(`UPDATE pending_notifications SET deliver_at = ?`, time.Now().UTC().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.queueNotification("eliminado", testEvent(EventDown), noon); err != nil {
+		t.Fatal(err)
+	}
+	s.flushPendingNotifications()
+	s.inFlight.Wait()
+	if sent := rec.take(); len(sent) != 0 {
+		t.Errorf("enviadas antes de tiempo: %+v", sent)
+	}
+	var pending int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM pending_notifications`).Scan(&pending); err != nil || pending != 1 {
+		t.Errorf("pendientes = %d, %v", pending, err)
+	}
+
+	if _, err := s.db.Exec(`UPDATE pending_notifications SET deliver_at = ?`, noon); err != nil {
+		t.Fatal(err)
+	}
+	s.flushPendingNotifications()
+	s.inFlight.Wait()
+	if sent := rec.take(); len(sent) != 1 || sent[0].Site != "api" || sent[0].IncidentID != 42 {
+		t.Errorf("enviadas = %+v", sent)
+	}
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM pending_notifications`).Scan(&pending); err != nil || pending != 0 {
+		t.Errorf("pendientes = %d, %v", pending, err)
+	}
+}
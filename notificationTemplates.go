@@ -0,0 +1,203 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Plantillas text/template del título y del mensaje de un canal
+type NotificationTemplates struct {
+	Title   string `json:"title"`
+	Message string `json:"message"`
+}
+
+// Resultado de renderizar las plantillas de un canal
+type NotificationPreview struct {
+	Title   string `json:"title"`
+	Message string `json:"message"`
+}
+
+// Datos disponibles en las plantillas: los campos del NotificationEvent
+// (.Type, .Site, .Check, .Incident, .Alert, .OccurredAt) más la duración, los
+// datos resumidos del check y el título ya renderizado (solo en el mensaje)
+type notificationTemplateData struct {
+	NotificationEvent
+	Duration time.Duration
+	Facts    []chatFact
+	Title    string
+}
+
+const defaultTitleTemplate = `
+{{- if and (eq .Type "recovery") .Flapping}}🔀 {{.Site.Name}} está oscilando
+{{- else if eq .Type "recovery"}}✅ {{.Site.Name}} se ha recuperado
+{{- else if eq .Type "alert_firing"}}⚠️ {{.Alert.Rule}}: {{.Site.Name}}
+{{- else if eq .Type "alert_resolved"}}✅ {{.Alert.Rule}} resuelta: {{.Site.Name}}
+{{- else if eq .Type "flapping"}}🔀 {{.Site.Name}} está oscilando
+{{- else if eq .Type "flapping_end"}}✅ {{.Site.Name}} ha dejado de oscilar
+{{- else if eq .Type "reminder"}}🔁 {{.Site.Name}} sigue caído
+{{- else if eq .Type "escalation"}}🚨 Escalado: {{.Site.Name}} sigue caído
+{{- else}}🔴 {{.Site.Name}} está caído{{end}}`
+
+const defaultPlainMessageTemplate = `{{.Summary}}
+{{.Site.URL}}
+{{- range .Facts}}
+{{.Name}}: {{.Value}}
+{{- end}}`
+
+const defaultMarkdownMessageTemplate = `{{.Summary}}
+{{- range .Facts}}
+**{{.Name}}**: {{.Value}}
+{{- end}}`
+
+// Plantillas por defecto según el tipo de canal
+var defaultNotificationTemplates = map[string]NotificationTemplates{
+	"webhook": {Title: defaultTitleTemplate, Message: `{{.Summary}}`},
+	"email": {
+		Title:   `{{if or (eq .Type "flapping") (and (eq .Type "recovery") .Flapping)}}[Oscilando] {{.Site.Name}}{{else if eq .Type "flapping_end"}}[Estable] {{.Site.Name}}{{else if .Alert}}[Alerta{{if .IsResolved}} resuelta{{end}}] {{.Alert.Rule}}: {{.Site.Name}}{{else}}{{if .IsResolved}}[Recuperado]{{else if eq .Type "reminder"}}[Recordatorio]{{else if eq .Type "escalation"}}[Escalado]{{else}}[Caído]{{end}} {{.Site.Name}}{{end}}`,
+		Message: defaultEmailTextTemplate,
+	},
+	"slack": {Title: defaultTitleTemplate, Message: `{{.Summary}}
+{{- range .Facts}}
+*{{.Name}}*: {{.Value}}
+{{- end}}`},
+	"discord":   {Title: defaultTitleTemplate, Message: defaultMarkdownMessageTemplate},
+	"teams":     {Title: defaultTitleTemplate, Message: defaultMarkdownMessageTemplate},
+	"telegram":  {Title: defaultTitleTemplate, Message: defaultPlainMessageTemplate},
+	"ntfy":      {Title: defaultTitleTemplate, Message: defaultPlainMessageTemplate},
+	"gotify":    {Title: defaultTitleTemplate, Message: defaultPlainMessageTemplate},
+	"pagerduty": {Title: `{{.Summary}}`, Message: defaultPlainMessageTemplate},
+}
+
+var defaultTitle = template.Must(template.New("title").Parse(defaultTitleTemplate))
+
+// eventTitle devuelve el título por defecto del evento
+func eventTitle(event NotificationEvent) string {
+	var buf bytes.Buffer
+	if err := defaultTitle.Execute(&buf, notificationTemplateData{NotificationEvent: event}); err != nil {
+		return event.Summary()
+	}
+	return buf.String()
+}
+
+// Plantillas compiladas de un canal
+type messageTemplates struct {
+	title   *template.Template
+	message *template.Template
+}
+
+// newMessageTemplates compila las plantillas del canal, usando las de por
+// defecto del tipo de canal cuando no están definidas
+func newMessageTemplates(channel NotificationChannel) (*messageTemplates, error) {
+	templates := GetDefaultNotificationTemplates(channel.Type)
+	if channel.Templates != nil {
+		if strings.TrimSpace(channel.Templates.Title) != "" {
+			templates.Title = channel.Templates.Title
+		}
+		if strings.TrimSpace(channel.Templates.Message) != "" {
+			templates.Message = channel.Templates.Message
+		}
+	}
+
+	title, err := template.New("title").Parse(templates.Title)
+	if err != nil {
+		return nil, fmt.Errorf("canal '%s': plantilla de título inválida: %w", channel.Name, err)
+	}
+	message, err := template.New("message").Parse(templates.Message)
+	if err != nil {
+		return nil, fmt.Errorf("canal '%s': plantilla de mensaje inválida: %w", channel.Name, err)
+	}
+
+	return &messageTemplates{title: title, message: message}, nil
+}
+
+// render ejecuta las plantillas con los datos del evento
+func (t *messageTemplates) render(event NotificationEvent) (NotificationPreview, error) {
+	data := notificationTemplateData{
+		NotificationEvent: event,
+		Duration:          event.Duration(),
+		Facts:             eventFacts(event),
+	}
+
+	var title, message bytes.Buffer
+	if err := t.title.Execute(&title, data); err != nil {
+		return NotificationPreview{}, &notificationRenderError{fmt.Errorf("error ejecutando plantilla de título: %w", err)}
+	}
+	data.Title = strings.TrimSpace(title.String())
+	if err := t.message.Execute(&message, data); err != nil {
+		return NotificationPreview{}, &notificationRenderError{fmt.Errorf("error ejecutando plantilla de mensaje: %w", err)}
+	}
+
+	return NotificationPreview{Title: data.Title, Message: strings.TrimSpace(message.String())}, nil
+}
+
+// GetDefaultNotificationTemplates devuelve las plantillas por defecto del tipo de canal
+func GetDefaultNotificationTemplates(channelType string) NotificationTemplates {
+	if templates, ok := defaultNotificationTemplates[channelType]; ok {
+		return templates
+	}
+	return NotificationTemplates{Title: defaultTitleTemplate, Message: defaultPlainMessageTemplate}
+}
+
+// sampleNotificationEvent genera un evento de ejemplo para pruebas y vistas previas
+func sampleNotificationEvent(eventType string) NotificationEvent {
+	now := time.Now().UTC()
+	site := Site{Name: "Prueba", URL: "https://example.com", Method: "GET"}
+	event := NotificationEvent{
+		Type: eventType,
+		Site: site,
+		Check: StatusCheck{
+			SiteName:     site.Name,
+			SiteURL:      site.URL,
+			Status:       "down",
+			StatusCode:   503,
+			CheckedAt:    now,
+			ErrorMessage: "Notificación de prueba",
+		},
+		Incident:   Incident{SiteName: site.Name, StartedAt: now, ErrorMessage: "Notificación de prueba"},
+		OccurredAt: now,
+	}
+
+	switch eventType {
+	case EventRecovery, EventAlertResolved:
+		resolvedAt := now
+		event.Incident.StartedAt = now.Add(-5 * time.Minute)
+		event.Incident.ResolvedAt = &resolvedAt
+		event.Check.Status = "up"
+		event.Check.StatusCode = 200
+		event.Check.ResponseTime = 120
+		event.Check.ErrorMessage = ""
+	case EventReminder, EventEscalation:
+		event.Incident.StartedAt = now.Add(-30 * time.Minute)
+	}
+	switch eventType {
+	case EventAlertFiring, EventAlertResolved:
+		event.Alert = &AlertInfo{Rule: "Prueba", Type: AlertFailures, Message: "3 fallos en los últimos 5 checks (límite 3)", Value: 3}
+	case EventFlapping, EventFlappingEnd:
+		event.Flapping = eventType == EventFlapping
+		event.Alert = &AlertInfo{Rule: "Oscilación", Type: "flapping", Message: "4 cambios de estado en los últimos 10 checks", Value: 4}
+	}
+	return event
+}
+
+// PreviewNotification renderiza el título y el mensaje que enviaría el canal
+// (aunque aún no esté guardado). Si el evento no indica sitio se usa uno de ejemplo.
+func (s *StatusPageService) PreviewNotification(channel NotificationChannel, event NotificationEvent) (NotificationPreview, error) {
+	if event.Type == "" {
+		event.Type = EventDown
+	}
+	if event.Site.Name == "" {
+		event = sampleNotificationEvent(event.Type)
+	}
+	if event.Alert == nil {
+		event.Alert = sampleNotificationEvent(event.Type).Alert
+	}
+
+	templates, err := newMessageTemplates(channel)
+	if err != nil {
+		return NotificationPreview{}, err
+	}
+	return templates.render(event)
+}
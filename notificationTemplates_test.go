@@ -0,0 +1,54 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+var allEventTypes = []string{EventDown, EventRecovery, EventAlertFiring, EventAlertResolved,
+	EventReminder, EventEscalation, EventFlapping, EventFlappingEnd}
+
+// La vista previa funciona para cualquier tipo de evento y canal, con el
+// evento de ejemplo o con un sitio real sin datos de alerta
+func TestPreviewNotificationAllEvents(t *testing.T) {
+	s := &StatusPageService{}
+	for channelType := range defaultNotificationTemplates {
+		for _, eventType := range allEventTypes {
+			t.Run(channelType+"/"+eventType, func(t *testing.T) {
+				channel := NotificationChannel{Name: "preview", Type: channelType}
+				for _, event := range []NotificationEvent{
+					{Type: eventType},
+					{Type: eventType, Site: Site{Name: "api", URL: "https://api.example.com"}},
+				} {
+					preview, err := s.PreviewNotification(channel, event)
+					if err != nil {
+						t.Fatalf("sitio %q: %v", event.Site.Name, err)
+					}
+					if strings.TrimSpace(preview.Title) == "" || strings.TrimSpace(preview.Message) == "" {
+						t.Errorf("sitio %q: vista previa vacía: %+v", event.Site.Name, preview)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestPreviewNotificationFlapping(t *testing.T) {
+	s := &StatusPageService{}
+	preview, err := s.PreviewNotification(NotificationChannel{Type: "email"}, NotificationEvent{Type: EventFlapping})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if preview.Title != "[Oscilando] Prueba" || !strings.Contains(preview.Message, "4 cambios de estado") {
+		t.Errorf("vista previa = %+v", preview)
+	}
+}
+
+func TestSummaryWithoutAlert(t *testing.T) {
+	for _, eventType := range allEventTypes {
+		event := NotificationEvent{Type: eventType, Site: Site{Name: "api"}}
+		if summary := event.Summary(); !strings.Contains(summary, "api") {
+			t.Errorf("%s: resumen = %q", eventType, summary)
+		}
+	}
+}
@@ -0,0 +1,496 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Tipos de evento de notificación
+const (
+	EventDown          = "down"
+	EventRecovery      = "recovery"
+	EventAlertFiring   = "alert_firing"
+	EventAlertResolved = "alert_resolved"
+	EventReminder      = "reminder"
+	EventEscalation    = "escalation"
+	EventFlapping      = "flapping"
+	EventFlappingEnd   = "flapping_end"
+)
+
+// Canal de notificación configurado en config.json
+type NotificationChannel struct {
+	Name    string   `json:"name"`
+	Type    string   `json:"type"` // "webhook", "email", "slack", "discord", "teams", "telegram", "ntfy", "gotify", "pagerduty"
+	Enabled bool     `json:"enabled"`
+	Retries int      `json:"retries,omitempty"` // reintentos ante fallo (por defecto 3)
+	Sites   []string `json:"sites,omitempty"`   // vacío = todos los sitios
+	Groups  []string `json:"groups,omitempty"`  // vacío = todos los grupos
+	// Solo recibe eventos enrutados por reglas de alerta o escalado
+	RoutedOnly bool `json:"routedOnly,omitempty"`
+	// Horario de envío; nil = siempre
+	Schedule *NotificationSchedule `json:"schedule,omitempty"`
+	// Plantillas del título y del mensaje; vacío = plantillas por defecto del tipo
+	Templates *NotificationTemplates `json:"templates,omitempty"`
+
+	Webhook   *WebhookConfig   `json:"webhook,omitempty"`
+	Email     *EmailConfig     `json:"email,omitempty"`
+	Slack     *ChatConfig      `json:"slack,omitempty"`
+	Discord   *ChatConfig      `json:"discord,omitempty"`
+	Teams     *ChatConfig      `json:"teams,omitempty"`
+	Telegram  *TelegramConfig  `json:"telegram,omitempty"`
+	Ntfy      *NtfyConfig      `json:"ntfy,omitempty"`
+	Gotify    *GotifyConfig    `json:"gotify,omitempty"`
+	PagerDuty *PagerDutyConfig `json:"pagerduty,omitempty"`
+}
+
+// Evento enviado a los canales cuando un sitio cambia de estado o cuando
+// una regla de alerta se activa o se resuelve
+type NotificationEvent struct {
+	Type       string      `json:"type"` // "down", "recovery", "alert_firing", "alert_resolved", "reminder", "escalation", "flapping", "flapping_end"
+	Site       Site        `json:"site"`
+	Check      StatusCheck `json:"check"`
+	Incident   Incident    `json:"incident"`
+	Alert      *AlertInfo  `json:"alert,omitempty"`
+	Flapping   bool        `json:"flapping,omitempty"` // el sitio oscila: no se notifica cada cambio
+	OccurredAt time.Time   `json:"occurredAt"`
+}
+
+// IsResolved indica si el evento informa de una recuperación
+func (e NotificationEvent) IsResolved() bool {
+	return e.Type == EventRecovery || e.Type == EventAlertResolved || e.Type == EventFlappingEnd
+}
+
+// Duration devuelve la duración de la caída asociada al evento
+func (e NotificationEvent) Duration() time.Duration {
+	return e.Incident.Duration()
+}
+
+// Summary devuelve una descripción corta del evento en texto plano
+func (e NotificationEvent) Summary() string {
+	// Los eventos de alerta y oscilación traen Alert, pero un evento
+	// construido desde la UI o la API puede no incluirlo
+	alert := AlertInfo{Rule: "sin nombre", Message: "sin detalle"}
+	if e.Alert != nil {
+		alert = *e.Alert
+	}
+
+	switch e.Type {
+	case EventAlertFiring:
+		return fmt.Sprintf("Alerta '%s' activada para %s: %s", alert.Rule, e.Site.Name, alert.Message)
+	case EventAlertResolved:
+		return fmt.Sprintf("Alerta '%s' resuelta para %s: %s", alert.Rule, e.Site.Name, alert.Message)
+	case EventFlapping:
+		return fmt.Sprintf("%s está oscilando: %s", e.Site.Name, alert.Message)
+	case EventFlappingEnd:
+		return fmt.Sprintf("%s ha dejado de oscilar (estado actual: %s)", e.Site.Name, e.Check.Status)
+	case EventReminder:
+		return fmt.Sprintf("%s sigue caído desde hace %s", e.Site.Name, e.Duration())
+	case EventEscalation:
+		return fmt.Sprintf("Escalado: %s lleva %s caído sin reconocer", e.Site.Name, e.Duration())
+	}
+	if e.Type == EventRecovery && e.Flapping {
+		return fmt.Sprintf("%s está oscilando: se cierra la alerta de caída hasta que se estabilice", e.Site.Name)
+	}
+	if e.Type == EventRecovery {
+		return fmt.Sprintf("%s se ha recuperado tras %s caído", e.Site.Name, e.Duration())
+	}
+	if e.Check.ErrorMessage != "" {
+		return fmt.Sprintf("%s está caído: %s", e.Site.Name, e.Check.ErrorMessage)
+	}
+	return fmt.Sprintf("%s está caído (HTTP %d)", e.Site.Name, e.Check.StatusCode)
+}
+
+// Notifier envía un evento a un destino concreto. Devuelve el contenido
+// enviado para registrarlo en el log de entregas.
+type Notifier interface {
+	Send(ctx context.Context, event NotificationEvent) (string, error)
+}
+
+// Registro de una entrega de notificación
+type NotificationDelivery struct {
+	ID          int64     `json:"id"`
+	Channel     string    `json:"channel"`
+	ChannelType string    `json:"channelType"`
+	SiteName    string    `json:"siteName"`
+	EventType   string    `json:"eventType"`
+	IncidentID  int64     `json:"incidentId"`
+	Status      string    `json:"status"` // "sent", "failed", "dropped"
+	Attempts    int       `json:"attempts"`
+	Payload     string    `json:"payload,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+var (
+	// Cliente HTTP compartido por los canales basados en HTTP
+	notificationHTTPClient = &http.Client{Timeout: 15 * time.Second}
+
+	// Espera antes del primer reintento; se duplica en cada intento
+	notificationRetryDelay = 2 * time.Second
+)
+
+const defaultNotificationRetries = 3
+
+// newNotifier construye el Notifier correspondiente al tipo de canal
+func newNotifier(channel NotificationChannel) (Notifier, error) {
+	templates, err := newMessageTemplates(channel)
+	if err != nil {
+		return nil, err
+	}
+
+	switch channel.Type {
+	case "webhook":
+		if channel.Webhook == nil {
+			return nil, fmt.Errorf("canal '%s': falta la configuración webhook", channel.Name)
+		}
+		return &WebhookNotifier{config: *channel.Webhook, templates: templates}, nil
+	case "email":
+		if channel.Email == nil {
+			return nil, fmt.Errorf("canal '%s': falta la configuración email", channel.Name)
+		}
+		customMessage := channel.Templates != nil && strings.TrimSpace(channel.Templates.Message) != ""
+		return &EmailNotifier{config: *channel.Email, templates: templates, customMessage: customMessage}, nil
+	case "slack":
+		if channel.Slack == nil {
+			return nil, fmt.Errorf("canal '%s': falta la configuración slack", channel.Name)
+		}
+		return &SlackNotifier{config: *channel.Slack, templates: templates}, nil
+	case "discord":
+		if channel.Discord == nil {
+			return nil, fmt.Errorf("canal '%s': falta la configuración discord", channel.Name)
+		}
+		return &DiscordNotifier{config: *channel.Discord, templates: templates}, nil
+	case "teams":
+		if channel.Teams == nil {
+			return nil, fmt.Errorf("canal '%s': falta la configuración teams", channel.Name)
+		}
+		return &TeamsNotifier{config: *channel.Teams, templates: templates}, nil
+	case "telegram":
+		if channel.Telegram == nil {
+			return nil, fmt.Errorf("canal '%s': falta la configuración telegram", channel.Name)
+		}
+		return &TelegramNotifier{config: *channel.Telegram, templates: templates}, nil
+	case "ntfy":
+		if channel.Ntfy == nil {
+			return nil, fmt.Errorf("canal '%s': falta la configuración ntfy", channel.Name)
+		}
+		return &NtfyNotifier{config: *channel.Ntfy, templates: templates}, nil
+	case "gotify":
+		if channel.Gotify == nil {
+			return nil, fmt.Errorf("canal '%s': falta la configuración gotify", channel.Name)
+		}
+		return &GotifyNotifier{config: *channel.Gotify, templates: templates}, nil
+	case "pagerduty":
+		if channel.PagerDuty == nil {
+			return nil, fmt.Errorf("canal '%s': falta la configuración pagerduty", channel.Name)
+		}
+		return &PagerDutyNotifier{config: *channel.PagerDuty, templates: templates}, nil
+	default:
+		return nil, fmt.Errorf("canal '%s': tipo de notificación desconocido '%s'", channel.Name, channel.Type)
+	}
+}
+
+// appliesTo indica si el canal debe notificar eventos del sitio
+func (c NotificationChannel) appliesTo(site Site) bool {
+	if len(c.Sites) == 0 && len(c.Groups) == 0 {
+		return true
+	}
+	for _, name := range c.Sites {
+		if name == site.Name {
+			return true
+		}
+	}
+	for _, group := range c.Groups {
+		if site.Group != "" && group == site.Group {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *StatusPageService) initNotificationsDB() error {
+	createTableSQL := `
+	CREATE TABLE IF NOT EXISTS notification_log (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		channel TEXT NOT NULL,
+		channel_type TEXT NOT NULL,
+		site_name TEXT NOT NULL,
+		event_type TEXT NOT NULL,
+		incident_id INTEGER,
+		status TEXT NOT NULL,
+		attempts INTEGER NOT NULL,
+		payload TEXT,
+		error TEXT,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_notification_log_created_at ON notification_log(created_at);
+	`
+
+	_, err := s.db.Exec(createTableSQL)
+	return err
+}
+
+// handleTransition abre o cierra el incidente del sitio según el resultado
+// del check y notifica los cambios up→down y down→up (salvo con quiet)
+func (s *StatusPageService) handleTransition(site Site, check StatusCheck, quiet bool) {
+	// Los checks "unreachable" no cambian el estado ni generan alertas
+	if check.Status != "up" && check.Status != "down" {
+		return
+	}
+
+	s.transitionMu.Lock()
+	defer s.transitionMu.Unlock()
+
+	incident, err := s.openIncident(site.Name)
+	if err != nil {
+		log.Printf("Error obteniendo incidente abierto para %s: %v", site.Name, err)
+		return
+	}
+
+	var event NotificationEvent
+	switch {
+	case check.Status == "down" && incident == nil:
+		incident, err = s.createIncident(site.Name, check.CheckedAt, check.ErrorMessage)
+		if err != nil {
+			log.Printf("Error creando incidente para %s: %v", site.Name, err)
+			return
+		}
+		event = NotificationEvent{Type: EventDown}
+	case check.Status == "up" && incident != nil:
+		if err := s.resolveIncident(incident, check.CheckedAt); err != nil {
+			log.Printf("Error resolviendo incidente para %s: %v", site.Name, err)
+			return
+		}
+		event = NotificationEvent{Type: EventRecovery}
+	default:
+		return
+	}
+
+	event.Site = site
+	event.Check = check
+	event.Incident = *incident
+	event.Flapping = s.isFlapping(site.Name)
+	event.OccurredAt = check.CheckedAt
+
+	if event.Flapping || quiet {
+		// Mientras oscila solo se envía la alerta de oscilación
+		log.Printf("Cambio de estado (oscilando, sin notificar): %s", event.Summary())
+		s.emitTransition(event)
+		return
+	}
+
+	log.Printf("Cambio de estado: %s", event.Summary())
+	s.notify(event)
+	if event.Type == EventRecovery {
+		s.notifyEscalatedRecovery(event)
+	}
+	s.emitTransition(event)
+}
+
+// notify envía el evento a todos los canales habilitados que apliquen al sitio
+func (s *StatusPageService) notify(event NotificationEvent) {
+	for _, channel := range s.currentConfig().Notifications {
+		if !channel.Enabled || channel.RoutedOnly || !channel.appliesTo(event.Site) {
+			continue
+		}
+		s.dispatch(channel, event)
+	}
+}
+
+// notifyChannels envía el evento a los canales habilitados indicados por nombre
+func (s *StatusPageService) notifyChannels(names []string, event NotificationEvent) {
+	for _, name := range names {
+		channel, ok := s.findNotificationChannel(name)
+		if !ok {
+			log.Printf("Canal de notificación '%s' no encontrado", name)
+			continue
+		}
+		if !channel.Enabled {
+			continue
+		}
+		s.dispatch(channel, event)
+	}
+}
+
+func (s *StatusPageService) findNotificationChannel(name string) (NotificationChannel, bool) {
+	for _, channel := range s.currentConfig().Notifications {
+		if channel.Name == name {
+			return channel, true
+		}
+	}
+	return NotificationChannel{}, false
+}
+
+// deliver envía el evento al canal con reintentos y backoff exponencial, y
+// registra el resultado en notification_log
+func (s *StatusPageService) deliver(channel NotificationChannel, event NotificationEvent) NotificationDelivery {
+	delivery := NotificationDelivery{
+		Channel:     channel.Name,
+		ChannelType: channel.Type,
+		SiteName:    event.Site.Name,
+		EventType:   event.Type,
+		IncidentID:  event.Incident.ID,
+		Status:      "failed",
+	}
+
+	notifier, err := newNotifier(channel)
+	if err != nil {
+		delivery.Error = err.Error()
+		s.saveNotificationDelivery(&delivery)
+		return delivery
+	}
+
+	retries := channel.Retries
+	if retries <= 0 {
+		retries = defaultNotificationRetries
+	}
+
+	// Los envíos y las esperas entre reintentos se cancelan al detener el servicio
+	ctx := s.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	delay := notificationRetryDelay
+attempts:
+	for attempt := 0; attempt <= retries; attempt++ {
+		if attempt > 0 {
+			timer := time.NewTimer(delay)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				log.Printf("Reintentos de notificación por '%s' cancelados: %v", channel.Name, ctx.Err())
+				break attempts
+			}
+			delay *= 2
+		}
+
+		attemptCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		payload, err := notifier.Send(attemptCtx, event)
+		cancel()
+
+		delivery.Attempts = attempt + 1
+		delivery.Payload = payload
+		if err == nil {
+			delivery.Status = "sent"
+			delivery.Error = ""
+			break
+		}
+
+		delivery.Error = err.Error()
+		log.Printf("Error enviando notificación por '%s' (intento %d/%d): %v",
+			channel.Name, attempt+1, retries+1, err)
+		if !retryableNotificationError(err) {
+			break
+		}
+	}
+
+	s.saveNotificationDelivery(&delivery)
+	return delivery
+}
+
+// notificationRenderError es un fallo al generar el mensaje (plantilla o JSON)
+type notificationRenderError struct {
+	err error
+}
+
+func (e *notificationRenderError) Error() string {
+	return e.err.Error()
+}
+
+func (e *notificationRenderError) Unwrap() error {
+	return e.err
+}
+
+// retryableNotificationError indica si vale la pena reintentar el envío: un
+// mensaje que no se pudo generar o un 4xx no cambian al repetir la petición,
+// salvo 408 (timeout) y 429 (límite)
+func retryableNotificationError(err error) bool {
+	var renderErr *notificationRenderError
+	if errors.As(err, &renderErr) {
+		return false
+	}
+	var httpErr *notificationHTTPError
+	if !errors.As(err, &httpErr) {
+		return true
+	}
+	switch {
+	case httpErr.StatusCode == http.StatusRequestTimeout, httpErr.StatusCode == http.StatusTooManyRequests:
+		return true
+	case httpErr.StatusCode >= 400 && httpErr.StatusCode < 500:
+		return false
+	}
+	return true
+}
+
+func (s *StatusPageService) saveNotificationDelivery(delivery *NotificationDelivery) {
+	delivery.CreatedAt = time.Now().UTC()
+
+	insertSQL := `
+	INSERT INTO notification_log (channel, channel_type, site_name, event_type, incident_id, status, attempts, payload, error, created_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	result, err := s.db.Exec(insertSQL, delivery.Channel, delivery.ChannelType, delivery.SiteName,
+		delivery.EventType, delivery.IncidentID, delivery.Status, delivery.Attempts,
+		delivery.Payload, delivery.Error, delivery.CreatedAt)
+	if err != nil {
+		log.Printf("Error guardando registro de notificación: %v", err)
+		return
+	}
+	delivery.ID, _ = result.LastInsertId()
+}
+
+// GetNotificationLog devuelve las últimas entregas de notificaciones
+func (s *StatusPageService) GetNotificationLog(limit int) ([]NotificationDelivery, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	query := `
+	SELECT id, channel, channel_type, site_name, event_type, incident_id, status, attempts,
+		   COALESCE(payload, ''), COALESCE(error, ''), created_at
+	FROM notification_log
+	ORDER BY created_at DESC, id DESC
+	LIMIT ?
+	`
+
+	rows, err := s.db.Query(query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []NotificationDelivery
+	for rows.Next() {
+		var d NotificationDelivery
+		err := rows.Scan(&d.ID, &d.Channel, &d.ChannelType, &d.SiteName, &d.EventType,
+			&d.IncidentID, &d.Status, &d.Attempts, &d.Payload, &d.Error, &d.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	return deliveries, rows.Err()
+}
+
+// TestNotificationChannel envía un evento de prueba al canal indicado
+func (s *StatusPageService) TestNotificationChannel(channelName string) (NotificationDelivery, error) {
+	if channel, ok := s.findNotificationChannel(channelName); ok {
+		delivery := s.deliver(channel, sampleNotificationEvent(EventDown))
+		if delivery.Status != "sent" {
+			return delivery, fmt.Errorf("no se pudo enviar la notificación de prueba: %s", delivery.Error)
+		}
+		return delivery, nil
+	}
+
+	return NotificationDelivery{}, fmt.Errorf("canal de notificación '%s' no encontrado", channelName)
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// codeServer responde con los códigos indicados, uno por petición; el último se repite
+func codeServer(t *testing.T, codes ...int) (*httptest.Server, func() int) {
+	t.Helper()
+	var mu sync.Mutex
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		code := codes[min(requests, len(codes)-1)]
+		requests++
+		w.WriteHeader(code)
+	}))
+	t.Cleanup(ts.Close)
+	return ts, func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return requests
+	}
+}
+
+func TestDeliverRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		codes        []int
+		retries      int
+		wantAttempts int
+		wantStatus   string
+		wantError    string
+	}{
+		{"éxito al primer intento", []int{200}, 3, 1, "sent", ""},
+		{"éxito tras errores 5xx", []int{503, 502, 200}, 3, 3, "sent", ""},
+		{"agota los reintentos", []int{500}, 2, 3, "failed", "respuesta HTTP 500"},
+		{"4xx sin reintentos", []int{404}, 3, 1, "failed", "respuesta HTTP 404"},
+		{"408 se reintenta", []int{408, 200}, 3, 2, "sent", ""},
+		{"429 se reintenta", []int{429, 429, 200}, 3, 3, "sent", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(t)
+			ts, requests := codeServer(t, tt.codes...)
+			channel := NotificationChannel{Name: "hook", Type: "webhook", Enabled: true, Retries: tt.retries,
+				Webhook: &WebhookConfig{URL: ts.URL}}
+			event := NotificationEvent{Type: EventDown, Site: Site{Name: "api", URL: "https://api.example.com"},
+				Check: StatusCheck{Status: "down", StatusCode: 503}, Incident: Incident{ID: 7}}
+
+			delivery := s.deliver(channel, event)
+			if delivery.Attempts != tt.wantAttempts || requests() != tt.wantAttempts {
+				t.Errorf("intentos = %d (peticiones %d), se esperaban %d", delivery.Attempts, requests(), tt.wantAttempts)
+			}
+			if delivery.Status != tt.wantStatus {
+				t.Errorf("estado = %q, se esperaba %q", delivery.Status, tt.wantStatus)
+			}
+			if !strings.HasPrefix(delivery.Error, tt.wantError) || (tt.wantError == "") != (delivery.Error == "") {
+				t.Errorf("error = %q, se esperaba %q", delivery.Error, tt.wantError)
+			}
+
+			log, err := s.GetNotificationLog(10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(log) != 1 {
+				t.Fatalf("registros = %d, se esperaba 1", len(log))
+			}
+			row := log[0]
+			if row.Channel != "hook" || row.ChannelType != "webhook" || row.SiteName != "api" ||
+				row.EventType != EventDown || row.IncidentID != 7 || row.Status != tt.wantStatus ||
+				row.Attempts != tt.wantAttempts || row.Error != delivery.Error || row.Payload == "" {
+				t.Errorf("registro = %+v", row)
+			}
+		})
+	}
+}
+
+func TestDeliverStopsRetryingOnShutdown(t *testing.T) {
+	s := newTestService(t)
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	ts, requests := codeServer(t, 503)
+	channel := NotificationChannel{Name: "hook", Type: "webhook", Enabled: true, Retries: 5,
+		Webhook: &WebhookConfig{URL: ts.URL}}
+
+	s.cancel()
+	delivery := s.deliver(channel, NotificationEvent{Type: EventDown, Site: Site{Name: "api"}})
+	if delivery.Status != "failed" || delivery.Attempts > 1 || requests() > 1 {
+		t.Errorf("entrega = %+v (peticiones %d), se esperaba un único intento fallido", delivery, requests())
+	}
+}
+
+// Un mensaje que no se puede generar falla igual en cada intento: no se reintenta
+func TestDeliverDoesNotRetryRenderErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		channel NotificationChannel
+	}{
+		{"plantilla webhook", NotificationChannel{Webhook: &WebhookConfig{Template: `{"site": {{.Missing}}}`}}},
+		{"plantilla de mensaje", NotificationChannel{Webhook: &WebhookConfig{},
+			Templates: &NotificationTemplates{Message: "{{.Site.Missing}}"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(t)
+			ts, requests := codeServer(t, http.StatusOK)
+			channel := tt.channel
+			channel.Name, channel.Type, channel.Enabled, channel.Retries = "hook", "webhook", true, 3
+			channel.Webhook.URL = ts.URL
+
+			delivery := s.deliver(channel, testEvent(EventDown))
+			if delivery.Status != "failed" || delivery.Attempts != 1 || requests() != 0 {
+				t.Errorf("entrega = %+v, peticiones = %d", delivery, requests())
+			}
+			if !strings.Contains(delivery.Error, "plantilla") {
+				t.Errorf("error = %q", delivery.Error)
+			}
+		})
+	}
+}
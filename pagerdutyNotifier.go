@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Configuración de PagerDuty Events API v2
+type PagerDutyConfig struct {
+	BaseURL    string `json:"baseUrl,omitempty"`  // por defecto https://events.pagerduty.com
+	RoutingKey string `json:"routingKey"`         // integration key del servicio
+	Severity   string `json:"severity,omitempty"` // "critical", "error", "warning", "info" (por defecto "critical")
+}
+
+type PagerDutyNotifier struct {
+	config    PagerDutyConfig
+	templates *messageTemplates
+}
+
+// pagerDutyDedupKey genera una clave estable por sitio e incidente (o por
+// sitio y regla de alerta), de modo que el evento "resolve" cierre el mismo
+// incidente abierto por el "trigger"
+func pagerDutyDedupKey(event NotificationEvent) string {
+	site := strings.ToLower(strings.Join(strings.Fields(event.Site.Name), "-"))
+	if event.Alert != nil {
+		rule := strings.ToLower(strings.Join(strings.Fields(event.Alert.Rule), "-"))
+		return fmt.Sprintf("statuspage-%s-alert-%s", site, rule)
+	}
+	return fmt.Sprintf("statuspage-%s-%d", site, event.Incident.ID)
+}
+
+func (n *PagerDutyNotifier) Send(ctx context.Context, event NotificationEvent) (string, error) {
+	payload := map[string]any{
+		"routing_key": n.config.RoutingKey,
+		"dedup_key":   pagerDutyDedupKey(event),
+	}
+
+	if event.IsResolved() {
+		payload["event_action"] = "resolve"
+	} else {
+		severity := n.config.Severity
+		if severity == "" {
+			severity = "critical"
+		}
+
+		rendered, err := n.templates.render(event)
+		if err != nil {
+			return "", err
+		}
+
+		details := map[string]any{
+			"message":       rendered.Message,
+			"url":           event.Site.URL,
+			"status_code":   event.Check.StatusCode,
+			"response_time": event.Check.ResponseTime,
+		}
+		if event.Check.ErrorMessage != "" {
+			details["error"] = event.Check.ErrorMessage
+		}
+
+		body := map[string]any{
+			"summary":        rendered.Title,
+			"source":         event.Site.URL,
+			"severity":       severity,
+			"timestamp":      event.OccurredAt.UTC().Format(time.RFC3339),
+			"component":      event.Site.Name,
+			"class":          pagerDutyClass(event),
+			"custom_details": details,
+		}
+		if event.Site.Group != "" {
+			body["group"] = event.Site.Group
+		}
+
+		payload["event_action"] = "trigger"
+		payload["payload"] = body
+		payload["links"] = []map[string]any{{"href": event.Site.URL, "text": event.Site.Name}}
+	}
+
+	url := baseURLOrDefault(n.config.BaseURL, "https://events.pagerduty.com") + "/v2/enqueue"
+	return postChatPayload(ctx, url, payload)
+}
+
+func pagerDutyClass(event NotificationEvent) string {
+	if event.Alert != nil {
+		return event.Alert.Type
+	}
+	return "site_down"
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func flappingEvent(eventType string) NotificationEvent {
+	event := testEvent(eventType)
+	event.Alert = &AlertInfo{Rule: "Oscilación", Type: "flapping", Message: "4 cambios de estado en los últimos 10 checks", Value: 4}
+	return event
+}
+
+func TestPagerDutyTriggerAndResolve(t *testing.T) {
+	tests := []struct {
+		name     string
+		trigger  NotificationEvent
+		resolve  NotificationEvent
+		dedupKey string
+		class    string
+	}{
+		{"caída", testEvent(EventDown), testEvent(EventRecovery), "statuspage-api-42", "site_down"},
+		{"alerta", alertEvent(EventAlertFiring), alertEvent(EventAlertResolved), "statuspage-api-alert-latencia", "response_time"},
+		{"oscilación", flappingEvent(EventFlapping), flappingEvent(EventFlappingEnd), "statuspage-api-alert-oscilación", "flapping"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newCaptureServer(t)
+			channel := NotificationChannel{Name: "pd", Type: "pagerduty",
+				PagerDuty: &PagerDutyConfig{BaseURL: ts.URL, RoutingKey: "R0UT1NG", Severity: "error"}}
+
+			payload, captured := sendCaptured(t, ts, channel, tt.trigger)
+			if captured.path != "/v2/enqueue" {
+				t.Errorf("ruta = %q", captured.path)
+			}
+			checkJSONPaths(t, payload, map[string]any{
+				"routing_key":       "R0UT1NG",
+				"event_action":      "trigger",
+				"dedup_key":         tt.dedupKey,
+				"payload.severity":  "error",
+				"payload.component": "api",
+				"payload.group":     "Payments",
+				"payload.class":     tt.class,
+				"payload.timestamp": "2024-05-01T10:05:00Z",
+			})
+
+			payload, _ = sendCaptured(t, ts, channel, tt.resolve)
+			checkJSONPaths(t, payload, map[string]any{
+				"event_action": "resolve",
+				"dedup_key":    tt.dedupKey,
+				"payload":      nil,
+			})
+		})
+	}
+}
+
+// Cada incidente tiene su propia clave y los recordatorios y escalados la reutilizan
+func TestPagerDutyDedupKey(t *testing.T) {
+	tests := []struct {
+		event NotificationEvent
+		want  string
+	}{
+		{testEvent(EventReminder), "statuspage-api-42"},
+		{testEvent(EventEscalation), "statuspage-api-42"},
+		{NotificationEvent{Type: EventDown, Site: Site{Name: "Payments API"}, Incident: Incident{ID: 7}}, "statuspage-payments-api-7"},
+		{NotificationEvent{Type: EventAlertFiring, Site: Site{Name: "Payments  API"},
+			Alert: &AlertInfo{Rule: "Certificado TLS"}}, "statuspage-payments-api-alert-certificado-tls"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s/%s", tt.event.Type, tt.event.Site.Name), func(t *testing.T) {
+			if got := pagerDutyDedupKey(tt.event); got != tt.want {
+				t.Errorf("dedup_key = %q, se esperaba %q", got, tt.want)
+			}
+		})
+	}
+}
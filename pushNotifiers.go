@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"strings"
+)
+
+// Configuración de un bot de Telegram
+type TelegramConfig struct {
+	BaseURL  string `json:"baseUrl,omitempty"` // por defecto https://api.telegram.org
+	BotToken string `json:"botToken"`
+	ChatID   string `json:"chatId"`
+}
+
+// Configuración de ntfy (ntfy.sh o servidor propio)
+type NtfyConfig struct {
+	BaseURL string `json:"baseUrl,omitempty"` // por defecto https://ntfy.sh
+	Topic   string `json:"topic"`
+	Token   string `json:"token,omitempty"` // token de acceso para topics protegidos
+}
+
+// Configuración de Gotify (siempre autoalojado)
+type GotifyConfig struct {
+	BaseURL string `json:"baseUrl"`
+	Token   string `json:"token"` // token de la aplicación
+}
+
+// Prioridades por tipo de evento: caída = alta, recuperación = normal
+const (
+	ntfyPriorityHigh     = 4
+	ntfyPriorityNormal   = 3
+	gotifyPriorityHigh   = 8
+	gotifyPriorityNormal = 5
+)
+
+func baseURLOrDefault(baseURL, defaultURL string) string {
+	if baseURL == "" {
+		baseURL = defaultURL
+	}
+	return strings.TrimRight(baseURL, "/")
+}
+
+type TelegramNotifier struct {
+	config    TelegramConfig
+	templates *messageTemplates
+}
+
+func (n *TelegramNotifier) Send(ctx context.Context, event NotificationEvent) (string, error) {
+	rendered, err := n.templates.render(event)
+	if err != nil {
+		return "", err
+	}
+	text := fmt.Sprintf("<b>%s</b>\n%s", html.EscapeString(rendered.Title), html.EscapeString(rendered.Message))
+
+	payload := map[string]any{
+		"chat_id":    n.config.ChatID,
+		"text":       text,
+		"parse_mode": "HTML",
+		// Telegram no tiene prioridades: las recuperaciones se envían sin sonido
+		"disable_notification":     event.IsResolved(),
+		"disable_web_page_preview": true,
+	}
+
+	url := fmt.Sprintf("%s/bot%s/sendMessage", baseURLOrDefault(n.config.BaseURL, "https://api.telegram.org"), n.config.BotToken)
+	return postChatPayload(ctx, url, payload)
+}
+
+type NtfyNotifier struct {
+	config    NtfyConfig
+	templates *messageTemplates
+}
+
+func (n *NtfyNotifier) Send(ctx context.Context, event NotificationEvent) (string, error) {
+	rendered, err := n.templates.render(event)
+	if err != nil {
+		return "", err
+	}
+
+	priority, tags := ntfyPriorityHigh, []string{"rotating_light"}
+	if event.IsResolved() {
+		priority, tags = ntfyPriorityNormal, []string{"white_check_mark"}
+	}
+
+	payload := map[string]any{
+		"topic":    n.config.Topic,
+		"title":    rendered.Title,
+		"message":  rendered.Message,
+		"priority": priority,
+		"tags":     tags,
+		"click":    event.Site.URL,
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", &notificationRenderError{err}
+	}
+
+	headers := map[string]string{"Content-Type": "application/json"}
+	if n.config.Token != "" {
+		headers["Authorization"] = "Bearer " + n.config.Token
+	}
+
+	// ntfy acepta publicaciones JSON en la raíz del servidor
+	url := baseURLOrDefault(n.config.BaseURL, "https://ntfy.sh")
+	return string(body), postNotification(ctx, http.MethodPost, url, headers, body)
+}
+
+type GotifyNotifier struct {
+	config    GotifyConfig
+	templates *messageTemplates
+}
+
+func (n *GotifyNotifier) Send(ctx context.Context, event NotificationEvent) (string, error) {
+	if n.config.BaseURL == "" {
+		return "", fmt.Errorf("falta la URL del servidor Gotify")
+	}
+
+	rendered, err := n.templates.render(event)
+	if err != nil {
+		return "", err
+	}
+
+	priority := gotifyPriorityHigh
+	if event.IsResolved() {
+		priority = gotifyPriorityNormal
+	}
+
+	payload := map[string]any{
+		"title":    rendered.Title,
+		"message":  rendered.Message,
+		"priority": priority,
+		"extras": map[string]any{
+			"client::notification": map[string]any{
+				"click": map[string]any{"url": event.Site.URL},
+			},
+		},
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", &notificationRenderError{err}
+	}
+
+	headers := map[string]string{
+		"Content-Type": "application/json",
+		"X-Gotify-Key": n.config.Token,
+	}
+
+	url := baseURLOrDefault(n.config.BaseURL, "") + "/message"
+	return string(body), postNotification(ctx, http.MethodPost, url, headers, body)
+}
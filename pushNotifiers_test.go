@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"testing"
+)
+
+// alertEvent es una alerta de ejemplo del tipo indicado
+func alertEvent(eventType string) NotificationEvent {
+	event := testEvent(eventType)
+	event.Alert = &AlertInfo{Rule: "Latencia", Type: "response_time", Message: "p95 > 800 ms", Value: 950}
+	return event
+}
+
+func TestPushNotifierPriorities(t *testing.T) {
+	tests := []struct {
+		name           string
+		event          NotificationEvent
+		ntfyPriority   int
+		ntfyTag        string
+		gotifyPriority int
+		telegramSilent bool
+	}{
+		{"caída", testEvent(EventDown), ntfyPriorityHigh, "rotating_light", gotifyPriorityHigh, false},
+		{"recuperación", testEvent(EventRecovery), ntfyPriorityNormal, "white_check_mark", gotifyPriorityNormal, true},
+		{"recordatorio", testEvent(EventReminder), ntfyPriorityHigh, "rotating_light", gotifyPriorityHigh, false},
+		{"escalado", testEvent(EventEscalation), ntfyPriorityHigh, "rotating_light", gotifyPriorityHigh, false},
+		{"alerta activada", alertEvent(EventAlertFiring), ntfyPriorityHigh, "rotating_light", gotifyPriorityHigh, false},
+		{"alerta resuelta", alertEvent(EventAlertResolved), ntfyPriorityNormal, "white_check_mark", gotifyPriorityNormal, true},
+		{"fin de oscilación", alertEvent(EventFlappingEnd), ntfyPriorityNormal, "white_check_mark", gotifyPriorityNormal, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newCaptureServer(t)
+
+			payload, captured := sendCaptured(t, ts, NotificationChannel{Name: "ntfy", Type: "ntfy",
+				Ntfy: &NtfyConfig{BaseURL: ts.URL + "/", Topic: "alertas", Token: "tk"}}, tt.event)
+			if captured.path != "/" || captured.header.Get("Authorization") != "Bearer tk" {
+				t.Errorf("ntfy: ruta %q, Authorization %q", captured.path, captured.header.Get("Authorization"))
+			}
+			checkJSONPaths(t, payload, map[string]any{
+				"topic":    "alertas",
+				"priority": tt.ntfyPriority,
+				"tags.0":   tt.ntfyTag,
+				"click":    "https://api.example.com",
+			})
+
+			payload, captured = sendCaptured(t, ts, NotificationChannel{Name: "gotify", Type: "gotify",
+				Gotify: &GotifyConfig{BaseURL: ts.URL, Token: "app-token"}}, tt.event)
+			if captured.path != "/message" || captured.header.Get("X-Gotify-Key") != "app-token" {
+				t.Errorf("gotify: ruta %q, X-Gotify-Key %q", captured.path, captured.header.Get("X-Gotify-Key"))
+			}
+			checkJSONPaths(t, payload, map[string]any{
+				"priority":                              tt.gotifyPriority,
+				"extras.client::notification.click.url": "https://api.example.com",
+			})
+
+			payload, captured = sendCaptured(t, ts, NotificationChannel{Name: "telegram", Type: "telegram",
+				Telegram: &TelegramConfig{BaseURL: ts.URL, BotToken: "123:abc", ChatID: "-100"}}, tt.event)
+			if captured.path != "/bot123:abc/sendMessage" {
+				t.Errorf("telegram: ruta %q", captured.path)
+			}
+			checkJSONPaths(t, payload, map[string]any{
+				"chat_id":              "-100",
+				"parse_mode":           "HTML",
+				"disable_notification": tt.telegramSilent,
+			})
+		})
+	}
+}
+
+// Telegram interpreta HTML: los textos del sitio se escapan
+func TestTelegramNotifierEscapesHTML(t *testing.T) {
+	ts := newCaptureServer(t)
+	event := testEvent(EventDown)
+	event.Site.Name = "<api & co>"
+
+	payload, _ := sendCaptured(t, ts, NotificationChannel{Name: "telegram", Type: "telegram",
+		Telegram: &TelegramConfig{BaseURL: ts.URL, BotToken: "t", ChatID: "1"}}, event)
+	checkJSONPaths(t, payload, map[string]any{
+		"text": "<b>🔴 &lt;api &amp; co&gt; está caído</b>\n&lt;api &amp; co&gt; está caído: HTTP 503\nhttps://api.example.com\n" +
+			"Código HTTP: 503\nTiempo de respuesta: 120 ms\nError: HTTP 503\nGrupo: Payments",
+	})
+}
+
+func TestGotifyNotifierRequiresBaseURL(t *testing.T) {
+	notifier, err := newNotifier(NotificationChannel{Name: "gotify", Type: "gotify", Gotify: &GotifyConfig{Token: "t"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := notifier.Send(context.Background(), testEvent(EventDown)); err == nil {
+		t.Error("se esperaba un error sin URL del servidor")
+	}
+}
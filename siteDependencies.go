@@ -0,0 +1,162 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// pruneUnknownDependencies elimina de DependsOn los sitios que no existen en
+// la configuración y las referencias duplicadas
+func pruneUnknownDependencies(sites []Site) []Site {
+	names := make(map[string]bool, len(sites))
+	for _, site := range sites {
+		names[site.Name] = true
+	}
+
+	for i := range sites {
+		var deps []string
+		seen := make(map[string]bool)
+		for _, dep := range sites[i].DependsOn {
+			dep = strings.TrimSpace(dep)
+			if seen[dep] {
+				continue
+			}
+			if !names[dep] {
+				log.Printf("Sitio '%s' depende de '%s', que no existe; se ignora la dependencia", sites[i].Name, dep)
+				continue
+			}
+			seen[dep] = true
+			deps = append(deps, dep)
+		}
+		sites[i].DependsOn = deps
+	}
+
+	return sites
+}
+
+// validateDependencies verifica que el grafo de dependencias no tenga ciclos
+func validateDependencies(sites []Site) error {
+	deps := make(map[string][]string, len(sites))
+	for _, site := range sites {
+		deps[site.Name] = site.DependsOn
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(sites))
+
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch state[name] {
+		case visiting:
+			return fmt.Errorf("dependencia circular entre sitios: %s", strings.Join(append(path, name), " -> "))
+		case visited:
+			return nil
+		}
+
+		state[name] = visiting
+		for _, dep := range deps[name] {
+			if err := visit(dep, append(path, name)); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		return nil
+	}
+
+	for _, site := range sites {
+		if err := visit(site.Name, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// dependencyLevels agrupa los sitios por nivel: el nivel 0 no tiene
+// dependencias y cada nivel solo depende de niveles anteriores.
+// Se asume que el grafo ya fue validado con validateDependencies.
+func dependencyLevels(sites []Site) [][]Site {
+	byName := make(map[string]Site, len(sites))
+	for _, site := range sites {
+		byName[site.Name] = site
+	}
+
+	depth := make(map[string]int, len(sites))
+	var levelOf func(site Site) int
+	levelOf = func(site Site) int {
+		if d, ok := depth[site.Name]; ok {
+			return d
+		}
+		d := 0
+		for _, dep := range site.DependsOn {
+			parent, ok := byName[dep]
+			if !ok || parent.Name == site.Name {
+				continue
+			}
+			if pd := levelOf(parent) + 1; pd > d {
+				d = pd
+			}
+		}
+		depth[site.Name] = d
+		return d
+	}
+
+	var levels [][]Site
+	for _, site := range sites {
+		d := levelOf(site)
+		for len(levels) <= d {
+			levels = append(levels, nil)
+		}
+		levels[d] = append(levels[d], site)
+	}
+
+	return levels
+}
+
+// downDependency devuelve el nombre de la primera dependencia del sitio cuyo
+// último estado registrado es "down" o "unreachable"
+func (s *StatusPageService) downDependency(site Site) (string, bool) {
+	for _, dep := range site.DependsOn {
+		var status string
+		err := s.db.QueryRow(`
+		SELECT status FROM status_checks
+		WHERE site_name = ?
+		ORDER BY checked_at DESC, id DESC
+		LIMIT 1
+		`, dep).Scan(&status)
+
+		if err != nil {
+			if err != sql.ErrNoRows {
+				log.Printf("Error obteniendo estado de la dependencia '%s': %v", dep, err)
+			}
+			continue
+		}
+
+		if status == "down" || status == "unreachable" {
+			return dep, true
+		}
+	}
+
+	return "", false
+}
+
+// markUnreachable marca el check como no alcanzable por la caída de parent
+func markUnreachable(check *StatusCheck, parent string) {
+	check.Status = "unreachable"
+	check.ErrorMessage = fmt.Sprintf("unreachable (dependency down: %s)", parent)
+}
+
+// SetSiteDependencies define los sitios de los que depende un sitio
+func (s *StatusPageService) SetSiteDependencies(name string, dependsOn []string) error {
+	return s.updateSite(name, func(c *Config, site *Site) error {
+		site.DependsOn = dependsOn
+		c.Sites = pruneUnknownDependencies(c.Sites)
+		return validateDependencies(c.Sites)
+	})
+}
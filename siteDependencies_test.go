@@ -0,0 +1,113 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestValidateDependencies(t *testing.T) {
+	tests := []struct {
+		name  string
+		sites []Site
+		cycle string
+	}{
+		{"sin dependencias", []Site{{Name: "a"}, {Name: "b"}}, ""},
+		{"cadena", []Site{{Name: "a", DependsOn: []string{"b"}}, {Name: "b", DependsOn: []string{"c"}}, {Name: "c"}}, ""},
+		{"diamante", []Site{{Name: "a", DependsOn: []string{"b", "c"}}, {Name: "b", DependsOn: []string{"d"}}, {Name: "c", DependsOn: []string{"d"}}, {Name: "d"}}, ""},
+		{"a sí mismo", []Site{{Name: "a", DependsOn: []string{"a"}}}, "a -> a"},
+		{"entre dos", []Site{{Name: "a", DependsOn: []string{"b"}}, {Name: "b", DependsOn: []string{"a"}}}, "a -> b -> a"},
+		{"entre tres", []Site{{Name: "a", DependsOn: []string{"b"}}, {Name: "b", DependsOn: []string{"c"}}, {Name: "c", DependsOn: []string{"a"}}}, "a -> b -> c -> a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateDependencies(tt.sites)
+			switch {
+			case tt.cycle == "" && err != nil:
+				t.Errorf("error inesperado: %v", err)
+			case tt.cycle != "" && (err == nil || !strings.Contains(err.Error(), tt.cycle)):
+				t.Errorf("error = %v, se esperaba el ciclo %q", err, tt.cycle)
+			}
+		})
+	}
+}
+
+func TestDependencyLevels(t *testing.T) {
+	sites := []Site{
+		{Name: "web", DependsOn: []string{"api", "vpn"}},
+		{Name: "api", DependsOn: []string{"db"}},
+		{Name: "blog"},
+		{Name: "db", DependsOn: []string{"vpn"}},
+		{Name: "vpn"},
+	}
+
+	var got []string
+	for _, level := range dependencyLevels(sites) {
+		var names []string
+		for _, site := range level {
+			names = append(names, site.Name)
+		}
+		got = append(got, strings.Join(names, ","))
+	}
+	// Dentro de cada nivel se conserva el orden de config.json
+	if want := "blog,vpn|db|api|web"; strings.Join(got, "|") != want {
+		t.Errorf("niveles = %q, se esperaba %q", strings.Join(got, "|"), want)
+	}
+}
+
+// Un sitio cuya dependencia está caída se registra como no alcanzable: no abre
+// incidente y no cuenta para el uptime
+func TestUnreachableWhenParentDown(t *testing.T) {
+	s := newTestService(t)
+	vpn, _ := codeServer(t, http.StatusServiceUnavailable)
+	app, _ := codeServer(t, http.StatusServiceUnavailable, http.StatusOK)
+	other, _ := codeServer(t, http.StatusServiceUnavailable)
+	err := s.updateConfig(func(c *Config) error {
+		c.Sites = []Site{
+			{Name: "vpn", URL: vpn.URL, Method: "GET", Timeout: 5},
+			{Name: "app", URL: app.URL, Method: "GET", Timeout: 5, DependsOn: []string{"vpn"}},
+			{Name: "other", URL: other.URL, Method: "GET", Timeout: 5},
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := s.currentConfig()
+
+	if check := s.checkSite(config.Sites[0]); check.Status != "down" {
+		t.Fatalf("vpn = %+v", check)
+	}
+	check := s.checkSite(config.Sites[1])
+	if check.Status != "unreachable" || check.ErrorMessage != "unreachable (dependency down: vpn)" {
+		t.Errorf("app = %+v", check)
+	}
+	if check := s.checkSite(config.Sites[2]); check.Status != "down" {
+		t.Errorf("un sitio sin dependencias debe registrarse como caído: %+v", check)
+	}
+
+	if incident, err := s.openIncident("app"); err != nil || incident != nil {
+		t.Errorf("app no debe tener incidente abierto: %+v, %v", incident, err)
+	}
+	if incident, err := s.openIncident("vpn"); err != nil || incident == nil {
+		t.Errorf("vpn debe tener un incidente abierto: %+v, %v", incident, err)
+	}
+
+	if check := s.checkSite(config.Sites[1]); check.Status != "up" {
+		t.Fatalf("app = %+v", check)
+	}
+	overview, err := s.GetAllStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stats DailyStats
+	for _, detail := range overview.Sites {
+		if detail.SiteName == "app" {
+			stats = detail.TotalStats
+		}
+	}
+	if stats.TotalChecks != 1 || stats.UnreachableChecks != 1 || stats.UptimePercent != 100 {
+		t.Errorf("estadísticas de app = %+v", stats)
+	}
+}
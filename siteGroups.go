@@ -0,0 +1,149 @@
+package main
+
+import (
+	"log"
+	"strings"
+)
+
+// Grupo de sitios (ej. "Payments", "Internal")
+type Group struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// Estado agregado de un grupo de sitios
+type GroupStatusDetail struct {
+	Name          string   `json:"name"`
+	Description   string   `json:"description,omitempty"`
+	Status        string   `json:"status"` // "up", "degraded", "down", "unknown"
+	TotalSites    int      `json:"totalSites"`
+	UpSites       int      `json:"upSites"`
+	DownSites     int      `json:"downSites"`
+	TotalChecks   int      `json:"totalChecks"`
+	UpChecks      int      `json:"upChecks"`
+	UptimePercent float64  `json:"uptimePercent"`
+	Sites         []string `json:"sites"`
+}
+
+// normalizeTags elimina espacios, etiquetas vacías y duplicados manteniendo el orden
+func normalizeTags(tags []string) []string {
+	var result []string
+	seen := make(map[string]bool)
+
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[strings.ToLower(tag)] {
+			continue
+		}
+		seen[strings.ToLower(tag)] = true
+		result = append(result, tag)
+	}
+
+	return result
+}
+
+// normalizeGroups mantiene el orden declarado de los grupos y agrega al final
+// los grupos que usan los sitios pero que no están declarados
+func normalizeGroups(groups []Group, sites []Site) []Group {
+	var result []Group
+	seen := make(map[string]bool)
+
+	for _, group := range groups {
+		group.Name = strings.TrimSpace(group.Name)
+		if group.Name == "" || seen[group.Name] {
+			continue
+		}
+		seen[group.Name] = true
+		result = append(result, group)
+	}
+
+	for _, site := range sites {
+		if site.Group == "" || seen[site.Group] {
+			continue
+		}
+		seen[site.Group] = true
+		result = append(result, Group{Name: site.Group})
+	}
+
+	return result
+}
+
+func siteHasTag(site Site, tag string) bool {
+	for _, t := range site.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// buildGroupStatus calcula el estado y uptime agregado de cada grupo a partir
+// del detalle de sus sitios, respetando el orden de los grupos en la configuración
+func buildGroupStatus(groups []Group, sites []SiteStatusDetail) []GroupStatusDetail {
+	result := make([]GroupStatusDetail, 0, len(groups))
+
+	for _, group := range groups {
+		detail := GroupStatusDetail{
+			Name:        group.Name,
+			Description: group.Description,
+			Sites:       []string{},
+		}
+
+		for _, site := range sites {
+			if site.Group != group.Name {
+				continue
+			}
+
+			detail.TotalSites++
+			detail.Sites = append(detail.Sites, site.SiteName)
+			detail.TotalChecks += site.TotalStats.TotalChecks
+			detail.UpChecks += site.TotalStats.UpChecks
+
+			switch site.LastStatus {
+			case "up":
+				detail.UpSites++
+			case "down":
+				detail.DownSites++
+			}
+		}
+
+		if detail.TotalChecks > 0 {
+			detail.UptimePercent = float64(detail.UpChecks) / float64(detail.TotalChecks) * 100
+		}
+
+		switch {
+		case detail.UpSites == 0 && detail.DownSites == 0:
+			detail.Status = "unknown"
+		case detail.DownSites == 0:
+			detail.Status = "up"
+		case detail.UpSites == 0:
+			detail.Status = "down"
+		default:
+			detail.Status = "degraded"
+		}
+
+		result = append(result, detail)
+	}
+
+	return result
+}
+
+// SetSiteGroup asigna el grupo y las etiquetas de un sitio
+func (s *StatusPageService) SetSiteGroup(name, group string, tags []string) error {
+	return s.updateSite(name, func(c *Config, site *Site) error {
+		site.Group = strings.TrimSpace(group)
+		site.Tags = normalizeTags(tags)
+		c.Groups = normalizeGroups(c.Groups, c.Sites)
+		return nil
+	})
+}
+
+// UpdateGroups reemplaza la lista de grupos; el orden recibido es el que se
+// guarda en config.json y se usa en GetAllStatus
+func (s *StatusPageService) UpdateGroups(groups []Group) error {
+	return s.updateConfig(func(c *Config) error {
+		c.Groups = normalizeGroups(groups, c.Sites)
+		log.Printf("Grupos actualizados: %d grupos configurados", len(c.Groups))
+		return nil
+	})
+}
@@ -0,0 +1,515 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Configuración de la página de estado pública
+type StatusPageConfig struct {
+	Title        string   `json:"title,omitempty"` // por defecto "Estado de los servicios"
+	Description  string   `json:"description,omitempty"`
+	LogoURL      string   `json:"logoUrl,omitempty"`
+	PublicURL    string   `json:"publicUrl,omitempty"`    // URL base de los enlaces de los feeds; por defecto la del request
+	PublicSites  []string `json:"publicSites,omitempty"`  // sitios visibles ("*" = todos); vacío junto a PublicGroups = ninguno
+	PublicGroups []string `json:"publicGroups,omitempty"` // grupos cuyos sitios son visibles
+}
+
+// Días que muestran las barras de disponibilidad
+const statusPageDays = 90
+
+func (c StatusPageConfig) title() string {
+	if c.Title == "" {
+		return "Estado de los servicios"
+	}
+	return c.Title
+}
+
+// isPublic indica si el sitio se muestra en la página de estado, los feeds y
+// los badges. Los sitios son privados salvo que se publiquen explícitamente.
+func (c StatusPageConfig) isPublic(site Site) bool {
+	if len(c.PublicSites) == 0 && len(c.PublicGroups) == 0 {
+		return false
+	}
+	if slices.Contains(c.PublicSites, "*") {
+		return true
+	}
+	return scopeIncludes(c.PublicSites, c.PublicGroups, site)
+}
+
+// Mensajes públicos por clase de error (ver checkErrorClass)
+var publicErrorMessages = map[string]string{
+	"http_5xx":           "El servicio responde con errores internos.",
+	"http_4xx":           "El servicio rechaza las peticiones.",
+	"timeout":            "El servicio tarda demasiado en responder.",
+	"dns":                "No se puede resolver la dirección del servicio.",
+	"connection_refused": "No se puede establecer conexión con el servicio.",
+	"connection_reset":   "La conexión con el servicio se interrumpe.",
+	"tls":                "Hay un problema con el certificado de seguridad del servicio.",
+	"dependency":         "Un servicio del que depende no está disponible.",
+}
+
+// publicErrorMessage describe la causa de la caída sin exponer el error
+// original, que puede incluir hosts, direcciones IP o respuestas internas
+func publicErrorMessage(check StatusCheck) string {
+	class := checkErrorClass(check)
+	// Los incidentes solo guardan el mensaje de error: sin él, la caída fue una respuesta HTTP de error
+	if check.StatusCode == 0 && check.ErrorMessage == "" && check.Status != "unreachable" {
+		class = "http_5xx"
+	}
+	if message, ok := publicErrorMessages[class]; ok {
+		return message
+	}
+	return "El servicio no responde correctamente."
+}
+
+type statusPageBar struct {
+	Level string // "up", "minor", "major", "down", "none"
+	Title string
+}
+
+type statusPageSite struct {
+	Name   string
+	Status string // "up", "down", "unreachable", "maintenance", "unknown"
+	Label  string
+	Uptime string
+	Bars   []statusPageBar
+}
+
+type statusPageGroup struct {
+	Name        string
+	Description string
+	Status      string
+	Label       string
+	Sites       []statusPageSite
+}
+
+type statusPageIncident struct {
+	Site         string
+	StartedAt    string
+	Duration     string
+	Message      string
+	Acknowledged bool
+}
+
+type statusPageAnnouncement struct {
+	Title     string
+	Status    string
+	Label     string
+	UpdatedAt string
+	Message   string
+}
+
+type statusPageMaintenance struct {
+	Title       string
+	Description string
+	InProgress  bool
+	Period      string
+	Affected    string
+}
+
+type statusPageData struct {
+	Title         string
+	Description   string
+	LogoURL       string
+	Status        string
+	Label         string
+	Incidents     []statusPageIncident
+	Announcements []statusPageAnnouncement
+	Maintenances  []statusPageMaintenance
+	Groups        []statusPageGroup
+	Days          int
+	GeneratedAt   string
+}
+
+var statusLabels = map[string]string{
+	"up":          "Operativo",
+	"degraded":    "Interrupción parcial",
+	"down":        "Caído",
+	"unreachable": "Sin conexión",
+	"maintenance": "En mantenimiento",
+	"unknown":     "Sin datos",
+}
+
+// uptimeBars devuelve una barra por día (más antigua primero) a partir de las estadísticas diarias
+func uptimeBars(stats []DailyStats, days int, now time.Time) []statusPageBar {
+	byDate := make(map[string]DailyStats, len(stats))
+	for _, stat := range stats {
+		byDate[stat.Date] = stat
+	}
+
+	bars := make([]statusPageBar, 0, days)
+	for i := days - 1; i >= 0; i-- {
+		date := now.UTC().AddDate(0, 0, -i).Format("2006-01-02")
+		stat, ok := byDate[date]
+		bar := statusPageBar{Level: "none", Title: date + ": sin datos"}
+		if ok && stat.TotalChecks > 0 {
+			switch {
+			case stat.UptimePercent >= 99.5:
+				bar.Level = "up"
+			case stat.UptimePercent >= 95:
+				bar.Level = "minor"
+			case stat.UptimePercent >= 50:
+				bar.Level = "major"
+			default:
+				bar.Level = "down"
+			}
+			bar.Title = fmt.Sprintf("%s: %.2f%% disponible", date, stat.UptimePercent)
+		}
+		bars = append(bars, bar)
+	}
+	return bars
+}
+
+// buildStatusPage reúne los datos de los sitios públicos para la página de estado
+func (s *StatusPageService) buildStatusPage() (*statusPageData, error) {
+	config := s.currentConfig()
+	cfg := config.StatusPage
+	now := time.Now()
+	data := &statusPageData{
+		Title:       cfg.title(),
+		Description: cfg.Description,
+		LogoURL:     cfg.LogoURL,
+		Days:        statusPageDays,
+		GeneratedAt: now.Format("2006-01-02 15:04 MST"),
+	}
+
+	sites, err := s.GetAllSites("")
+	if err != nil {
+		return nil, err
+	}
+	maintenances, err := s.GetMaintenances(false)
+	if err != nil {
+		return nil, err
+	}
+
+	public := make(map[string]bool)
+	var publicSites []Site
+	for _, site := range config.Sites {
+		if cfg.isPublic(site) {
+			public[site.Name] = true
+			publicSites = append(publicSites, site)
+		}
+	}
+
+	// Mantenimientos que afectan a algún sitio público
+	inMaintenance := make(map[string]bool)
+	for _, m := range maintenances {
+		var affected []string
+		for _, site := range publicSites {
+			if m.Affects(site) {
+				affected = append(affected, site.Name)
+				if m.Status() == "in_progress" {
+					inMaintenance[site.Name] = true
+				}
+			}
+		}
+		if len(affected) == 0 {
+			continue
+		}
+		entry := statusPageMaintenance{
+			Title:       m.Title,
+			Description: m.Description,
+			InProgress:  m.Status() == "in_progress",
+			Period:      m.StartsAt.Local().Format("2006-01-02 15:04") + " – " + m.EndsAt.Local().Format("2006-01-02 15:04 MST"),
+		}
+		if len(affected) == len(publicSites) {
+			entry.Affected = "Todos los servicios"
+		} else {
+			entry.Affected = strings.Join(affected, ", ")
+		}
+		data.Maintenances = append(data.Maintenances, entry)
+	}
+
+	// Sitios agrupados en el orden declarado; los sitios sin grupo van al final
+	groupIndex := make(map[string]int)
+	for _, group := range config.Groups {
+		groupIndex[group.Name] = len(data.Groups)
+		data.Groups = append(data.Groups, statusPageGroup{Name: group.Name, Description: group.Description})
+	}
+
+	upSites, downSites := 0, 0
+	for _, site := range sites {
+		if !public[site.Name] {
+			continue
+		}
+
+		entry := statusPageSite{Name: site.Name, Status: site.Status, Uptime: "–"}
+		if entry.Status == "" {
+			entry.Status = "unknown"
+		}
+		switch {
+		case inMaintenance[site.Name]:
+			entry.Status = "maintenance"
+		case entry.Status == "up":
+			upSites++
+		case entry.Status == "down":
+			downSites++
+		}
+		entry.Label = statusLabels[entry.Status]
+
+		stats, err := s.siteDailyStats(site.Name, statusPageDays)
+		if err != nil {
+			log.Printf("Error obteniendo estadísticas diarias para %s: %v", site.Name, err)
+		}
+		var total, up int
+		for _, stat := range stats {
+			total += stat.TotalChecks
+			up += stat.UpChecks
+		}
+		if total > 0 {
+			entry.Uptime = fmt.Sprintf("%.2f%%", float64(up)/float64(total)*100)
+		}
+		entry.Bars = uptimeBars(stats, statusPageDays, now)
+
+		idx, ok := groupIndex[site.Group]
+		if !ok {
+			idx = len(data.Groups)
+			groupIndex[site.Group] = idx
+			data.Groups = append(data.Groups, statusPageGroup{Name: "Otros servicios"})
+		}
+		data.Groups[idx].Sites = append(data.Groups[idx].Sites, entry)
+	}
+
+	// Descartar grupos sin sitios públicos y calcular el estado de cada grupo
+	groups := data.Groups[:0]
+	for _, group := range data.Groups {
+		if len(group.Sites) == 0 {
+			continue
+		}
+		group.Status = aggregateStatus(group.Sites)
+		group.Label = statusLabels[group.Status]
+		groups = append(groups, group)
+	}
+	data.Groups = groups
+
+	switch {
+	case downSites > 0 && upSites == 0:
+		data.Status, data.Label = "down", "Interrupción total de los servicios"
+	case downSites > 0:
+		data.Status, data.Label = "degraded", "Algunos servicios presentan problemas"
+	case len(inMaintenance) > 0:
+		data.Status, data.Label = "maintenance", "Mantenimiento en curso"
+	case upSites > 0:
+		data.Status, data.Label = "up", "Todos los servicios operativos"
+	default:
+		data.Status, data.Label = "unknown", "Sin datos de estado"
+	}
+
+	// Incidentes abiertos de sitios públicos
+	incidents, err := s.GetIncidents("", 100)
+	if err != nil {
+		return nil, err
+	}
+	lastChecks := make(map[string]StatusCheck, len(sites))
+	for _, site := range sites {
+		lastChecks[site.Name] = StatusCheck{Status: site.Status, StatusCode: site.StatusCode, ErrorMessage: site.ErrorMessage}
+	}
+	for _, incident := range incidents {
+		if !incident.IsOpen() || !public[incident.SiteName] {
+			continue
+		}
+		// El último check del sitio describe la caída en curso
+		check, ok := lastChecks[incident.SiteName]
+		if !ok || check.Status == "up" {
+			check = StatusCheck{Status: "down", ErrorMessage: incident.ErrorMessage}
+		}
+		data.Incidents = append(data.Incidents, statusPageIncident{
+			Site:         incident.SiteName,
+			StartedAt:    incident.StartedAt.Local().Format("2006-01-02 15:04 MST"),
+			Duration:     incident.Duration().String(),
+			Message:      publicErrorMessage(check),
+			Acknowledged: incident.AcknowledgedAt != nil,
+		})
+	}
+
+	// Incidentes manuales sin resolver que afectan a algún sitio público
+	manual, err := s.GetManualIncidents(50)
+	if err != nil {
+		return nil, err
+	}
+	for _, incident := range manual {
+		if incident.Status == IncidentResolved || len(incident.Updates) == 0 {
+			continue
+		}
+		affectsPublic := false
+		for _, site := range publicSites {
+			affectsPublic = affectsPublic || incident.Affects(site)
+		}
+		if !affectsPublic {
+			continue
+		}
+		latest := incident.Updates[0]
+		data.Announcements = append(data.Announcements, statusPageAnnouncement{
+			Title:     incident.Title,
+			Status:    incident.Status,
+			Label:     incidentStatusLabels[incident.Status],
+			UpdatedAt: latest.CreatedAt.Local().Format("2006-01-02 15:04 MST"),
+			Message:   latest.Message,
+		})
+	}
+
+	return data, nil
+}
+
+// aggregateStatus resume el estado de los sitios de un grupo
+func aggregateStatus(sites []statusPageSite) string {
+	up, down, maintenance := 0, 0, 0
+	for _, site := range sites {
+		switch site.Status {
+		case "up":
+			up++
+		case "down":
+			down++
+		case "maintenance":
+			maintenance++
+		}
+	}
+	switch {
+	case down > 0 && up == 0 && maintenance == 0:
+		return "down"
+	case down > 0:
+		return "degraded"
+	case maintenance > 0:
+		return "maintenance"
+	case up > 0:
+		return "up"
+	}
+	return "unknown"
+}
+
+// handleStatusPage sirve la página de estado pública (HTML sin JavaScript)
+func (s *StatusPageService) handleStatusPage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "método no permitido", http.StatusMethodNotAllowed)
+		return
+	}
+
+	data, err := s.buildStatusPage()
+	if err != nil {
+		log.Printf("Error generando la página de estado: %v", err)
+		http.Error(w, "error generando la página de estado", http.StatusInternalServerError)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := statusPageTemplate.Execute(&buf, data); err != nil {
+		log.Printf("Error generando la página de estado: %v", err)
+		http.Error(w, "error generando la página de estado", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "public, max-age=30")
+	w.Write(buf.Bytes())
+}
+
+var statusPageTemplate = template.Must(template.New("statusPage").Parse(`<!DOCTYPE html>
+<html lang="es">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta http-equiv="refresh" content="60">
+<title>{{.Title}}</title>
+<link rel="alternate" type="application/rss+xml" title="{{.Title}} (RSS)" href="/feeds/rss.xml">
+<link rel="alternate" type="application/atom+xml" title="{{.Title}} (Atom)" href="/feeds/atom.xml">
+<style>
+body{font-family:-apple-system,"Segoe UI",Roboto,Helvetica,Arial,sans-serif;background:#f5f6f8;color:#1f2328;margin:0}
+main{max-width:860px;margin:0 auto;padding:32px 16px}
+header{display:flex;align-items:center;gap:12px;margin-bottom:8px}
+header img{max-height:40px}
+h1{font-size:24px;margin:0}
+h2{font-size:18px;margin:32px 0 12px}
+.description{color:#57606a;margin:0 0 24px}
+.banner{border-radius:8px;padding:16px 20px;color:#fff;font-weight:600;font-size:18px;margin:24px 0}
+.card{background:#fff;border:1px solid #d0d7de;border-radius:8px;padding:16px 20px;margin-bottom:12px}
+.group-header{display:flex;justify-content:space-between;align-items:baseline;margin-bottom:4px}
+.group-header h3{margin:0;font-size:16px}
+.muted{color:#57606a;font-size:13px}
+.site{padding:12px 0;border-top:1px solid #eaeef2}
+.site:first-of-type{border-top:none}
+.site-header{display:flex;justify-content:space-between;margin-bottom:6px}
+.bars{display:flex;gap:2px;height:28px}
+.bars span{flex:1;border-radius:2px}
+.legend{display:flex;justify-content:space-between;margin-top:4px}
+.up{background:#2da44e}.minor{background:#d4a72c}.major{background:#e16f24}.down{background:#cf222e}
+.none,.unknown{background:#d0d7de}.degraded{background:#e16f24}.maintenance{background:#0969da}.unreachable{background:#8c959f}
+.label-up{color:#1a7f37}.label-down{color:#cf222e}.label-degraded{color:#bc4c00}.label-maintenance{color:#0969da}
+.label-unreachable,.label-unknown{color:#57606a}
+footer{margin-top:32px;text-align:center}
+</style>
+</head>
+<body>
+<main>
+<header>
+{{if .LogoURL}}<img src="{{.LogoURL}}" alt="">{{end}}
+<h1>{{.Title}}</h1>
+</header>
+{{if .Description}}<p class="description">{{.Description}}</p>{{end}}
+
+<div class="banner {{.Status}}">{{.Label}}</div>
+
+{{if or .Announcements .Incidents}}
+<h2>Incidentes activos</h2>
+{{range .Announcements}}
+<div class="card">
+<strong>{{.Title}}</strong> <span class="label-degraded">{{.Label}}</span>
+<div class="muted">Actualizado {{.UpdatedAt}}</div>
+<div>{{.Message}}</div>
+</div>
+{{end}}
+{{range .Incidents}}
+<div class="card">
+<strong>{{.Site}}</strong> <span class="label-down">Caído</span>{{if .Acknowledged}} <span class="muted">· en investigación</span>{{end}}
+<div class="muted">Desde {{.StartedAt}} ({{.Duration}})</div>
+{{if .Message}}<div>{{.Message}}</div>{{end}}
+</div>
+{{end}}
+{{end}}
+
+{{if .Maintenances}}
+<h2>Mantenimientos</h2>
+{{range .Maintenances}}
+<div class="card">
+<strong>{{.Title}}</strong> {{if .InProgress}}<span class="label-maintenance">En curso</span>{{else}}<span class="muted">Programado</span>{{end}}
+<div class="muted">{{.Period}} · {{.Affected}}</div>
+{{if .Description}}<div>{{.Description}}</div>{{end}}
+</div>
+{{end}}
+{{end}}
+
+<h2>Servicios</h2>
+{{range .Groups}}
+<section class="card">
+<div class="group-header">
+<h3>{{.Name}}</h3>
+<span class="label-{{.Status}}">{{.Label}}</span>
+</div>
+{{if .Description}}<div class="muted">{{.Description}}</div>{{end}}
+{{range .Sites}}
+<div class="site">
+<div class="site-header">
+<span>{{.Name}}</span>
+<span class="label-{{.Status}}">{{.Label}}</span>
+</div>
+<div class="bars">{{range .Bars}}<span class="{{.Level}}" title="{{.Title}}"></span>{{end}}</div>
+<div class="legend muted"><span>Hace {{$.Days}} días</span><span>{{.Uptime}} disponible</span><span>Hoy</span></div>
+</div>
+{{end}}
+</section>
+{{else}}
+<div class="card muted">No hay servicios publicados.</div>
+{{end}}
+
+<footer class="muted">Actualizado: {{.GeneratedAt}} · Suscribirse: <a href="/feeds/rss.xml">RSS</a> · <a href="/feeds/atom.xml">Atom</a></footer>
+</main>
+</body>
+</html>
+`))
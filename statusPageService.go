@@ -4,51 +4,84 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"log"
 	"net"
 	"net/http"
 	"os"
+	"slices"
+	"sync"
 	"time"
 
 	_ "modernc.org/sqlite"
 )
 
+// Errores que la API traduce a 404 y 409; se envuelven con %w para conservar el detalle
+var (
+	errNotFound      = errors.New("no encontrado")
+	errAlreadyExists = errors.New("ya existe")
+)
+
 type Config struct {
-	CheckInterval int    `json:"checkInterval"` // intervalo en segundos
-	RetentionDays int    `json:"retentionDays"` // días de retención de datos
-	Sites         []Site `json:"sites"`
+	CheckInterval int     `json:"checkInterval"`    // intervalo en segundos
+	RetentionDays int     `json:"retentionDays"`    // días de retención de datos
+	Groups        []Group `json:"groups,omitempty"` // orden de los grupos en el dashboard
+	Sites         []Site  `json:"sites"`
+
+	Notifications []NotificationChannel     `json:"notifications,omitempty"`
+	Desktop       DesktopNotificationConfig `json:"desktop"`
+	AlertRules    []AlertRule               `json:"alertRules,omitempty"`
+	Escalations   []EscalationPolicy        `json:"escalations,omitempty"`
+	Flapping      FlappingConfig            `json:"flapping"`
+	HTTP          HTTPServerConfig          `json:"http"`
+	StatusPage    StatusPageConfig          `json:"statusPage"`
 }
 
 type Site struct {
-	Name    string `json:"name"`
-	URL     string `json:"url"`
-	Method  string `json:"method"`
-	Timeout int    `json:"timeout"`
+	Name      string   `json:"name"`
+	URL       string   `json:"url"`
+	Method    string   `json:"method"`
+	Timeout   int      `json:"timeout"`
+	Group     string   `json:"group,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+	DependsOn []string `json:"dependsOn,omitempty"` // sitios de los que depende (ej. VPN)
+
+	MuteDesktop bool   `json:"muteDesktop,omitempty"` // sin notificaciones de escritorio
+	Severity    string `json:"severity,omitempty"`    // "critical", "normal" (por defecto), "low"
 }
 
 type StatusCheck struct {
 	ID           int       `json:"id"`
 	SiteName     string    `json:"siteName"`
 	SiteURL      string    `json:"siteUrl"`
-	Status       string    `json:"status"` // "up", "down"
+	Status       string    `json:"status"` // "up", "down", "unreachable"
 	StatusCode   int       `json:"statusCode"`
 	ResponseTime int64     `json:"responseTime"` // en milisegundos
 	CheckedAt    time.Time `json:"checkedAt"`
 	ErrorMessage string    `json:"errorMessage,omitempty"`
+
+	CertExpiresAt *time.Time `json:"certExpiresAt,omitempty"` // expiración del certificado TLS
 }
 
 type SiteDetail struct {
-	Name         string `json:"name"`
-	URL          string `json:"url"`
-	Method       string `json:"method"`
-	Timeout      int    `json:"timeout"`
-	Status       string `json:"status,omitempty"`
-	StatusCode   int    `json:"statusCode,omitempty"`
-	ResponseTime int64  `json:"responseTime,omitempty"`
-	LastChecked  string `json:"lastChecked,omitempty"`
-	ErrorMessage string `json:"errorMessage,omitempty"`
-	IsActive     bool   `json:"isActive"`
+	Name         string   `json:"name"`
+	URL          string   `json:"url"`
+	Method       string   `json:"method"`
+	Timeout      int      `json:"timeout"`
+	Group        string   `json:"group,omitempty"`
+	Tags         []string `json:"tags,omitempty"`
+	DependsOn    []string `json:"dependsOn,omitempty"`
+	MuteDesktop  bool     `json:"muteDesktop"`
+	Severity     string   `json:"severity"`
+	Flapping     bool     `json:"flapping"` // el estado cambia con demasiada frecuencia
+	Status       string   `json:"status,omitempty"`
+	StatusCode   int      `json:"statusCode,omitempty"`
+	ResponseTime int64    `json:"responseTime,omitempty"`
+	LastChecked  string   `json:"lastChecked,omitempty"`
+	ErrorMessage string   `json:"errorMessage,omitempty"`
+	IsActive     bool     `json:"isActive"`
 }
 
 type SiteStats struct {
@@ -61,9 +94,30 @@ type SiteStats struct {
 }
 
 type StatusPageService struct {
-	db     *sql.DB
-	config Config
+	db *sql.DB
+
+	// config se reemplaza completa al modificarse (copia al escribir); los
+	// lectores usan currentConfig y los escritores updateConfig
+	configMu sync.RWMutex
+	config   Config
+
 	ctx    context.Context
+	cancel context.CancelFunc
+
+	httpServer *http.Server
+
+	// Checks y entregas de notificaciones en curso, para el apagado ordenado
+	inFlight sync.WaitGroup
+
+	// Serializa la apertura/cierre de incidentes entre checks concurrentes
+	transitionMu sync.Mutex
+	alertsMu     sync.Mutex
+
+	listenersMu         sync.Mutex
+	transitionListeners []func(NotificationEvent)
+
+	metrics metricsRegistry
+	events  eventBus
 }
 
 func NewStatusPageService() *StatusPageService {
@@ -83,11 +137,60 @@ func NewStatusPageService() *StatusPageService {
 }
 
 func (s *StatusPageService) Start(ctx context.Context) {
-	s.ctx = ctx
+	s.ctx, s.cancel = context.WithCancel(ctx)
 	log.Println("Iniciando servicio de monitoreo...")
 
 	// Iniciar monitoreo en background
-	go s.startMonitoring()
+	s.goTracked(s.startMonitoring)
+	s.startHTTPServer()
+}
+
+// goTracked ejecuta fn en una goroutine que el apagado ordenado espera
+func (s *StatusPageService) goTracked(fn func()) {
+	s.inFlight.Add(1)
+	go func() {
+		defer s.inFlight.Done()
+		fn()
+	}()
+}
+
+// Tiempo máximo de espera de checks y notificaciones en curso al apagar
+const shutdownTimeout = 30 * time.Second
+
+// OnShutdown detiene el monitoreo, espera a los checks y notificaciones en
+// curso y cierra la base de datos. Wails lo llama al cerrar la aplicación.
+func (s *StatusPageService) OnShutdown() error {
+	if s.cancel != nil {
+		s.cancel()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	s.stopHTTPServer(ctx)
+
+	if s.waitInFlight(ctx) {
+		log.Println("Servicio de monitoreo detenido")
+	} else {
+		log.Printf("Tiempo de espera agotado (%s) esperando checks en curso", shutdownTimeout)
+	}
+
+	return s.db.Close()
+}
+
+// waitInFlight espera los checks y entregas en curso; devuelve false si ctx vence antes
+func (s *StatusPageService) waitInFlight(ctx context.Context) bool {
+	done := make(chan struct{})
+	go func() {
+		s.inFlight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-ctx.Done():
+		return false
+	}
 }
 
 func (s *StatusPageService) loadConfig() error {
@@ -116,7 +219,7 @@ func (s *StatusPageService) loadConfig() error {
 		// Si no existe el archivo, crear uno por defecto
 		log.Println("Archivo config.json no encontrado, creando configuración por defecto...")
 		s.config = defaultConfig
-		return s.saveConfig()
+		return writeConfigFile(s.config)
 	}
 	defer file.Close()
 
@@ -149,22 +252,105 @@ func (s *StatusPageService) loadConfig() error {
 		if s.config.Sites[i].Method == "" {
 			s.config.Sites[i].Method = "GET"
 		}
+		s.config.Sites[i].Tags = normalizeTags(s.config.Sites[i].Tags)
+		switch s.config.Sites[i].Severity {
+		case "", SeverityCritical, SeverityNormal, SeverityLow:
+		default:
+			log.Printf("Severidad inválida '%s' en %s, usando 'normal'", s.config.Sites[i].Severity, s.config.Sites[i].Name)
+			s.config.Sites[i].Severity = ""
+		}
+	}
+
+	// Validar dependencias entre sitios (referencias y ciclos)
+	s.config.Sites = pruneUnknownDependencies(s.config.Sites)
+	if err := validateDependencies(s.config.Sites); err != nil {
+		return err
+	}
+
+	// Registrar grupos referenciados por sitios pero no declarados
+	s.config.Groups = normalizeGroups(s.config.Groups, s.config.Sites)
+
+	// Validar horarios de los canales de notificación
+	for _, channel := range s.config.Notifications {
+		if channel.Schedule == nil {
+			continue
+		}
+		if err := channel.Schedule.validate(channel.Name, s.config.Notifications); err != nil {
+			return fmt.Errorf("canal '%s': %w", channel.Name, err)
+		}
+	}
+
+	// Validar reglas de alerta
+	if err := validateAlertRules(s.config.AlertRules, s.config.Notifications); err != nil {
+		return err
 	}
 
 	return nil
 }
 
-func (s *StatusPageService) saveConfig() error {
-	bytes, err := json.MarshalIndent(s.config, "", "  ")
+func writeConfigFile(config Config) error {
+	bytes, err := json.MarshalIndent(config, "", "  ")
 	if err != nil {
 		return err
 	}
 	return ioutil.WriteFile("config.json", bytes, 0644)
 }
 
+// clone copia las listas de la configuración para modificarlas sin afectar a
+// los lectores de la copia anterior
+func (c Config) clone() Config {
+	c.Groups = slices.Clone(c.Groups)
+	c.Sites = slices.Clone(c.Sites)
+	c.Notifications = slices.Clone(c.Notifications)
+	c.AlertRules = slices.Clone(c.AlertRules)
+	c.Escalations = slices.Clone(c.Escalations)
+	c.StatusPage.PublicSites = slices.Clone(c.StatusPage.PublicSites)
+	c.StatusPage.PublicGroups = slices.Clone(c.StatusPage.PublicGroups)
+	return c
+}
+
+// currentConfig devuelve la configuración vigente. Las listas no deben
+// modificarse: se comparten con las demás copias.
+func (s *StatusPageService) currentConfig() Config {
+	s.configMu.RLock()
+	defer s.configMu.RUnlock()
+	return s.config
+}
+
+// updateConfig aplica fn sobre una copia de la configuración, la guarda en
+// config.json y solo entonces la publica; si fn o el guardado fallan no cambia
+// nada. fn debe asignar listas nuevas en lugar de modificar las de los sitios.
+func (s *StatusPageService) updateConfig(fn func(c *Config) error) error {
+	s.configMu.Lock()
+	defer s.configMu.Unlock()
+
+	config := s.config.clone()
+	if err := fn(&config); err != nil {
+		return err
+	}
+	if err := writeConfigFile(config); err != nil {
+		return err
+	}
+	s.config = config
+	return nil
+}
+
+// updateSite aplica fn sobre el sitio indicado con updateConfig
+func (s *StatusPageService) updateSite(name string, fn func(c *Config, site *Site) error) error {
+	return s.updateConfig(func(c *Config) error {
+		for i := range c.Sites {
+			if c.Sites[i].Name == name {
+				return fn(c, &c.Sites[i])
+			}
+		}
+		return fmt.Errorf("sitio '%s' no encontrado", name)
+	})
+}
+
 func (s *StatusPageService) initDB() error {
 	var err error
-	s.db, err = sql.Open("sqlite", "./status.db")
+	// busy_timeout evita errores SQLITE_BUSY con escrituras concurrentes
+	s.db, err = sql.Open("sqlite", "./status.db?_pragma=busy_timeout(5000)")
 	if err != nil {
 		return err
 	}
@@ -186,12 +372,75 @@ func (s *StatusPageService) initDB() error {
 	`
 
 	_, err = s.db.Exec(createTableSQL)
+	if err != nil {
+		return err
+	}
+
+	// Columnas agregadas en versiones posteriores
+	if err := s.addColumnIfMissing("status_checks", "cert_expires_at", "DATETIME"); err != nil {
+		return err
+	}
+
+	if err := s.initIncidentsDB(); err != nil {
+		return err
+	}
+	if err := s.initNotificationsDB(); err != nil {
+		return err
+	}
+	if err := s.initAlertRulesDB(); err != nil {
+		return err
+	}
+	if err := s.initFlappingDB(); err != nil {
+		return err
+	}
+	if err := s.initDailyStatsDB(); err != nil {
+		return err
+	}
+	if err := s.initMaintenanceDB(); err != nil {
+		return err
+	}
+	if err := s.initManualIncidentsDB(); err != nil {
+		return err
+	}
+	if err := s.initAPITokensDB(); err != nil {
+		return err
+	}
+
+	return s.initPendingNotificationsDB()
+}
+
+// addColumnIfMissing agrega una columna a una tabla existente si aún no existe
+func (s *StatusPageService) addColumnIfMissing(table, column, definition string) error {
+	rows, err := s.db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var cid, notNull, pk int
+		var name, colType string
+		var defaultValue sql.NullString
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &defaultValue, &pk); err != nil {
+			return err
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+
+	_, err = s.db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
 	return err
 }
 
 func (s *StatusPageService) startMonitoring() {
-	log.Printf("Iniciando monitoreo cada %d segundos", s.config.CheckInterval)
-	log.Printf("Retención de datos: %d días", s.config.RetentionDays)
+	config := s.currentConfig()
+	log.Printf("Iniciando monitoreo cada %d segundos", config.CheckInterval)
+	log.Printf("Retención de datos: %d días", config.RetentionDays)
 
 	// Hacer check inicial
 	s.checkAllSites()
@@ -200,19 +449,26 @@ func (s *StatusPageService) startMonitoring() {
 	s.cleanupOldData()
 
 	// Configurar ticker para checks periódicos
-	ticker := time.NewTicker(time.Duration(s.config.CheckInterval) * time.Second)
+	ticker := time.NewTicker(time.Duration(config.CheckInterval) * time.Second)
 	defer ticker.Stop()
 
 	// Configurar ticker para limpieza diaria
 	cleanupTicker := time.NewTicker(24 * time.Hour)
 	defer cleanupTicker.Stop()
 
+	// Configurar ticker para enviar notificaciones retrasadas por horario
+	pendingTicker := time.NewTicker(time.Minute)
+	defer pendingTicker.Stop()
+
 	for {
 		select {
-		case <-ticker.C:
+		case tick := <-ticker.C:
+			s.metrics.setSchedulerLag(time.Since(tick))
 			s.checkAllSites()
 		case <-cleanupTicker.C:
 			s.cleanupOldData()
+		case <-pendingTicker.C:
+			s.flushPendingNotifications()
 		case <-s.ctx.Done():
 			return
 		}
@@ -246,12 +502,58 @@ func (s *StatusPageService) checkAllSites() {
 		return
 	}
 
-	for _, site := range s.config.Sites {
-		go s.checkSite(site)
+	// Verificar por niveles de dependencia: los sitios padre se guardan antes
+	// de verificar a sus dependientes
+	levels := dependencyLevels(s.currentConfig().Sites)
+	for _, level := range levels {
+		s.metrics.addQueue(len(level))
+	}
+	for _, level := range levels {
+		var wg sync.WaitGroup
+		for _, site := range level {
+			wg.Add(1)
+			go func(site Site) {
+				defer wg.Done()
+				s.metrics.addQueue(-1)
+				s.checkSite(site)
+			}(site)
+		}
+		wg.Wait()
+	}
+}
+
+// checkSite verifica el sitio y guarda el resultado
+func (s *StatusPageService) checkSite(site Site) StatusCheck {
+	s.metrics.addInFlight(1)
+	defer s.metrics.addInFlight(-1)
+
+	check := s.probeSite(site)
+	s.metrics.observeCheck(check)
+	// log.Printf("Checked %s: %s (%d) - %dms", site.Name, check.Status, check.StatusCode, check.ResponseTime)
+	return s.saveStatusCheck(site, check)
+}
+
+// probeSite verifica el sitio sin guardar el resultado
+func (s *StatusPageService) probeSite(site Site) StatusCheck {
+	check := s.performCheck(site)
+
+	// Si el sitio cae porque una dependencia está caída no se registra como caída
+	if check.Status == "down" {
+		if parent, ok := s.downDependency(site); ok {
+			markUnreachable(&check, parent)
+		}
 	}
+
+	return check
 }
 
-func (s *StatusPageService) checkSite(site Site) {
+// performCheck realiza la petición HTTP al sitio y devuelve el resultado
+func (s *StatusPageService) performCheck(site Site) StatusCheck {
+	check := StatusCheck{
+		SiteName: site.Name,
+		SiteURL:  site.URL,
+		Status:   "down",
+	}
 	start := time.Now()
 
 	client := &http.Client{
@@ -260,53 +562,101 @@ func (s *StatusPageService) checkSite(site Site) {
 
 	req, err := http.NewRequest(site.Method, site.URL, nil)
 	if err != nil {
-		s.saveStatusCheck(site, "down", 0, 0, err.Error())
-		return
+		check.ErrorMessage = err.Error()
+		return check
 	}
 
 	resp, err := client.Do(req)
-	responseTime := time.Since(start).Milliseconds()
+	check.ResponseTime = time.Since(start).Milliseconds()
 
 	if err != nil {
-		s.saveStatusCheck(site, "down", 0, responseTime, err.Error())
-		return
+		check.ErrorMessage = err.Error()
+		return check
 	}
 	defer resp.Body.Close()
 
-	status := "up"
-	if resp.StatusCode >= 400 {
-		status = "down"
+	check.StatusCode = resp.StatusCode
+	if resp.StatusCode < 400 {
+		check.Status = "up"
 	}
 
-	s.saveStatusCheck(site, status, resp.StatusCode, responseTime, "")
+	// Guardar la fecha de expiración del certificado TLS
+	if resp.TLS != nil && len(resp.TLS.PeerCertificates) > 0 {
+		expiresAt := resp.TLS.PeerCertificates[0].NotAfter.UTC()
+		check.CertExpiresAt = &expiresAt
+	}
 
-	// log.Printf("Checked %s: %s (%d) - %dms", site.Name, status, resp.StatusCode, responseTime)
+	return check
 }
 
-func (s *StatusPageService) saveStatusCheck(site Site, status string, statusCode int, responseTime int64, errorMsg string) {
+// saveStatusCheck guarda el check, dispara transiciones y alertas y lo devuelve con su ID
+func (s *StatusPageService) saveStatusCheck(site Site, check StatusCheck) StatusCheck {
 	insertSQL := `
-	INSERT INTO status_checks (site_name, site_url, status, status_code, response_time, error_message)
-	VALUES (?, ?, ?, ?, ?, ?)
+	INSERT INTO status_checks (site_name, site_url, status, status_code, response_time, error_message, cert_expires_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?)
 	`
 
-	_, err := s.db.Exec(insertSQL, site.Name, site.URL, status, statusCode, responseTime, errorMsg)
+	var certExpiresAt any
+	if check.CertExpiresAt != nil {
+		certExpiresAt = *check.CertExpiresAt
+	}
+
+	check.CheckedAt = time.Now().UTC()
+	start := time.Now()
+	result, err := s.db.Exec(insertSQL, site.Name, site.URL, check.Status, check.StatusCode,
+		check.ResponseTime, check.ErrorMessage, certExpiresAt)
+	s.metrics.observeDBWrite(time.Since(start))
 	if err != nil {
 		log.Printf("Error guardando status check: %v", err)
+		return check
+	}
+
+	if id, err := result.LastInsertId(); err == nil {
+		check.ID = int(id)
 	}
+
+	s.publishCheck(site, check)
+	flapping := s.evaluateFlapping(site, check)
+	// El cambio que cierra la oscilación no se notifica: lo concilia reconcileNotifications
+	s.handleTransition(site, check, flapping == flappingEnded)
+	if flapping != flappingUnchanged {
+		s.reconcileNotifications(site, check)
+	}
+	s.processEscalation(site, check)
+	s.evaluateAlertRules(site, check)
+	return check
 }
 
 func (s *StatusPageService) cleanupOldData() {
-	if s.config.RetentionDays <= 0 {
+	config := s.currentConfig()
+	if config.RetentionDays <= 0 {
 		log.Println("Limpieza deshabilitada (retentionDays <= 0)")
 		return
 	}
 
-	cutoffDate := time.Now().AddDate(0, 0, -s.config.RetentionDays)
+	cutoffDate := time.Now().AddDate(0, 0, -config.RetentionDays)
+
+	// Conservar el agregado diario de los checks que se eliminan
+	tx, err := s.db.Begin()
+	if err != nil {
+		log.Printf("Error durante limpieza de datos antiguos: %v", err)
+		return
+	}
+	if err := rollupDailyStats(tx, cutoffDate); err != nil {
+		tx.Rollback()
+		log.Printf("Error agregando estadísticas diarias: %v", err)
+		return
+	}
 
 	deleteSQL := `DELETE FROM status_checks WHERE checked_at < ?`
 
-	result, err := s.db.Exec(deleteSQL, cutoffDate)
+	result, err := tx.Exec(deleteSQL, cutoffDate)
 	if err != nil {
+		tx.Rollback()
+		log.Printf("Error durante limpieza de datos antiguos: %v", err)
+		return
+	}
+	if err := tx.Commit(); err != nil {
 		log.Printf("Error durante limpieza de datos antiguos: %v", err)
 		return
 	}
@@ -319,23 +669,33 @@ func (s *StatusPageService) cleanupOldData() {
 
 	if rowsDeleted > 0 {
 		log.Printf("Limpieza completada: %d registros eliminados (más antiguos que %d días)",
-			rowsDeleted, s.config.RetentionDays)
+			rowsDeleted, config.RetentionDays)
+	}
+
+	if _, err := s.db.Exec(`DELETE FROM notification_log WHERE created_at < ?`, cutoffDate.UTC()); err != nil {
+		log.Printf("Error durante limpieza del registro de notificaciones: %v", err)
+	}
+	if _, err := s.db.Exec(`DELETE FROM daily_stats WHERE date < DATE('now', ?)`, fmt.Sprintf("-%d days", dailyStatsRetentionDays)); err != nil {
+		log.Printf("Error durante limpieza de estadísticas diarias: %v", err)
 	}
 }
 
 // Estructura para estadísticas diarias
 type DailyStats struct {
-	Date          string  `json:"date"`
-	TotalChecks   int     `json:"totalChecks"`
-	UpChecks      int     `json:"upChecks"`
-	DownChecks    int     `json:"downChecks"`
-	UptimePercent float64 `json:"uptimePercent"`
+	Date              string  `json:"date"`
+	TotalChecks       int     `json:"totalChecks"` // no incluye checks "unreachable"
+	UpChecks          int     `json:"upChecks"`
+	DownChecks        int     `json:"downChecks"`
+	UnreachableChecks int     `json:"unreachableChecks"`
+	UptimePercent     float64 `json:"uptimePercent"`
 }
 
 // Estructura para el status completo de un sitio
 type SiteStatusDetail struct {
 	SiteName         string       `json:"siteName"`
 	SiteURL          string       `json:"siteUrl"`
+	Group            string       `json:"group,omitempty"`
+	Tags             []string     `json:"tags,omitempty"`
 	LastStatus       string       `json:"lastStatus"`
 	LastStatusCode   int          `json:"lastStatusCode"`
 	LastResponseTime int64        `json:"lastResponseTime"`
@@ -345,8 +705,15 @@ type SiteStatusDetail struct {
 	TotalStats       DailyStats   `json:"totalStats"`
 }
 
+// Estructura con el status de todos los sitios y el agregado por grupo
+type StatusOverview struct {
+	Groups []GroupStatusDetail `json:"groups"`
+	Sites  []SiteStatusDetail  `json:"sites"`
+}
+
 // Métodos expuestos al frontend
-func (s *StatusPageService) GetAllStatus() ([]SiteStatusDetail, error) {
+func (s *StatusPageService) GetAllStatus() (*StatusOverview, error) {
+	config := s.currentConfig()
 	var siteDetails []SiteStatusDetail
 
 	// Obtener todos los sitios únicos
@@ -368,6 +735,10 @@ func (s *StatusPageService) GetAllStatus() ([]SiteStatusDetail, error) {
 			SiteName: siteName,
 			SiteURL:  siteURL,
 		}
+		if site, ok := s.findSite(siteName); ok {
+			siteDetail.Group = site.Group
+			siteDetail.Tags = site.Tags
+		}
 
 		// Obtener el último status del sitio
 		lastStatusQuery := `
@@ -388,50 +759,27 @@ func (s *StatusPageService) GetAllStatus() ([]SiteStatusDetail, error) {
 		}
 
 		// Obtener estadísticas diarias (últimos 30 días)
-		dailyStatsQuery := `
-		SELECT DATE(checked_at) as check_date,
-			   COUNT(*) as total_checks,
-			   SUM(CASE WHEN status = 'up' THEN 1 ELSE 0 END) as up_checks,
-			   SUM(CASE WHEN status = 'down' THEN 1 ELSE 0 END) as down_checks
-		FROM status_checks
-		WHERE site_name = ? AND checked_at >= DATE('now', '-30 days')
-		GROUP BY DATE(checked_at)
-		ORDER BY check_date DESC
-		`
-
-		statsRows, err := s.db.Query(dailyStatsQuery, siteName)
+		dailyStats, err := s.siteDailyStats(siteName, 30)
 		if err != nil {
 			log.Printf("Error obteniendo estadísticas diarias para %s: %v", siteName, err)
 			continue
 		}
 
-		var dailyStats []DailyStats
-		var totalChecks, totalUpChecks, totalDownChecks int
-
-		for statsRows.Next() {
-			var stat DailyStats
-			err := statsRows.Scan(&stat.Date, &stat.TotalChecks, &stat.UpChecks, &stat.DownChecks)
-			if err != nil {
-				continue
-			}
-
-			if stat.TotalChecks > 0 {
-				stat.UptimePercent = float64(stat.UpChecks) / float64(stat.TotalChecks) * 100
-			}
-
-			dailyStats = append(dailyStats, stat)
+		var totalChecks, totalUpChecks, totalDownChecks, totalUnreachableChecks int
+		for _, stat := range dailyStats {
 			totalChecks += stat.TotalChecks
 			totalUpChecks += stat.UpChecks
 			totalDownChecks += stat.DownChecks
+			totalUnreachableChecks += stat.UnreachableChecks
 		}
-		statsRows.Close()
 
 		// Calcular estadísticas totales
 		siteDetail.TotalStats = DailyStats{
-			Date:        "total",
-			TotalChecks: totalChecks,
-			UpChecks:    totalUpChecks,
-			DownChecks:  totalDownChecks,
+			Date:              "total",
+			TotalChecks:       totalChecks,
+			UpChecks:          totalUpChecks,
+			DownChecks:        totalDownChecks,
+			UnreachableChecks: totalUnreachableChecks,
 		}
 		if totalChecks > 0 {
 			siteDetail.TotalStats.UptimePercent = float64(totalUpChecks) / float64(totalChecks) * 100
@@ -441,7 +789,12 @@ func (s *StatusPageService) GetAllStatus() ([]SiteStatusDetail, error) {
 		siteDetails = append(siteDetails, siteDetail)
 	}
 
-	return siteDetails, nil
+	overview := &StatusOverview{
+		Groups: buildGroupStatus(config.Groups, siteDetails),
+		Sites:  siteDetails,
+	}
+
+	return overview, nil
 }
 
 func (s *StatusPageService) GetSiteStatus(siteName string) ([]StatusCheck, error) {
@@ -474,16 +827,28 @@ func (s *StatusPageService) GetSiteStatus(siteName string) ([]StatusCheck, error
 	return checks, nil
 }
 
-func (s *StatusPageService) GetAllSites() ([]SiteDetail, error) {
+// GetAllSites devuelve los sitios configurados. Si tag no está vacío solo
+// se incluyen los sitios que tengan esa etiqueta.
+func (s *StatusPageService) GetAllSites(tag string) ([]SiteDetail, error) {
 	var sites []SiteDetail
 
-	for _, site := range s.config.Sites {
+	for _, site := range s.currentConfig().Sites {
+		if tag != "" && !siteHasTag(site, tag) {
+			continue
+		}
+
 		detail := SiteDetail{
-			Name:     site.Name,
-			URL:      site.URL,
-			Method:   site.Method,
-			Timeout:  site.Timeout,
-			IsActive: true,
+			Name:        site.Name,
+			URL:         site.URL,
+			Method:      site.Method,
+			Timeout:     site.Timeout,
+			Group:       site.Group,
+			Tags:        site.Tags,
+			DependsOn:   site.DependsOn,
+			MuteDesktop: site.MuteDesktop,
+			Severity:    siteSeverity(site),
+			Flapping:    s.isFlapping(site.Name),
+			IsActive:    true,
 		}
 
 		query := `
@@ -516,6 +881,7 @@ func (s *StatusPageService) GetAllSites() ([]SiteDetail, error) {
 }
 
 func (s *StatusPageService) GetStats() (map[string]interface{}, error) {
+	config := s.currentConfig()
 	var totalRecords int
 	var oldestRecord, newestRecord time.Time
 
@@ -538,7 +904,8 @@ func (s *StatusPageService) GetStats() (map[string]interface{}, error) {
 	}
 
 	siteStatsQuery := `
-	SELECT site_name, COUNT(*) as total_checks,
+	SELECT site_name,
+		   SUM(CASE WHEN status IN ('up', 'down') THEN 1 ELSE 0 END) as total_checks,
 		   SUM(CASE WHEN status = 'up' THEN 1 ELSE 0 END) as up_checks,
 		   SUM(CASE WHEN status = 'down' THEN 1 ELSE 0 END) as down_checks,
 		   AVG(response_time) as avg_response_time
@@ -573,8 +940,8 @@ func (s *StatusPageService) GetStats() (map[string]interface{}, error) {
 		"totalRecords":  totalRecords,
 		"oldestRecord":  oldestRecord,
 		"newestRecord":  newestRecord,
-		"retentionDays": s.config.RetentionDays,
-		"checkInterval": s.config.CheckInterval,
+		"retentionDays": config.RetentionDays,
+		"checkInterval": config.CheckInterval,
 		"siteStats":     siteStats,
 		"generatedAt":   time.Now(),
 	}
@@ -583,7 +950,17 @@ func (s *StatusPageService) GetStats() (map[string]interface{}, error) {
 }
 
 func (s *StatusPageService) GetConfig() Config {
-	return s.config
+	return s.currentConfig()
+}
+
+// findSite busca un sitio de la configuración por nombre
+func (s *StatusPageService) findSite(name string) (Site, bool) {
+	for _, site := range s.currentConfig().Sites {
+		if site.Name == name {
+			return site, true
+		}
+	}
+	return Site{}, false
 }
 
 func (s *StatusPageService) AddSite(name, url, method string, timeout int) error {
@@ -594,20 +971,44 @@ func (s *StatusPageService) AddSite(name, url, method string, timeout int) error
 		Timeout: timeout,
 	}
 
-	s.config.Sites = append(s.config.Sites, newSite)
-	return s.saveConfig()
+	return s.updateConfig(func(c *Config) error {
+		// Se comprueba dentro de updateConfig para que dos altas simultáneas no dupliquen el sitio
+		if slices.ContainsFunc(c.Sites, func(site Site) bool { return site.Name == name }) {
+			return fmt.Errorf("el sitio '%s' %w", name, errAlreadyExists)
+		}
+		c.Sites = append(c.Sites, newSite)
+		return nil
+	})
+}
+
+// UpdateSite modifica la URL, el método y el timeout de un sitio
+func (s *StatusPageService) UpdateSite(name, url, method string, timeout int) error {
+	return s.updateSite(name, func(c *Config, site *Site) error {
+		site.URL = url
+		site.Method = method
+		site.Timeout = timeout
+		return nil
+	})
 }
 
 func (s *StatusPageService) RemoveSite(name string) error {
-	// Primero verificar si el sitio existe
+	// Quitar el sitio de la configuración y de las dependencias de otros sitios
 	siteExists := false
-	for i, site := range s.config.Sites {
-		if site.Name == name {
-			// Remover el sitio de la configuración
-			s.config.Sites = append(s.config.Sites[:i], s.config.Sites[i+1:]...)
-			siteExists = true
-			break
+	err := s.updateConfig(func(c *Config) error {
+		sites := make([]Site, 0, len(c.Sites))
+		for _, site := range c.Sites {
+			if site.Name == name {
+				siteExists = true
+				continue
+			}
+			sites = append(sites, site)
 		}
+		c.Sites = pruneUnknownDependencies(sites)
+		return nil
+	})
+	if err != nil {
+		log.Printf("Error guardando configuración después de eliminar sitio '%s': %v", name, err)
+		return err
 	}
 
 	if !siteExists {
@@ -620,7 +1021,7 @@ func (s *StatusPageService) RemoveSite(name string) error {
 	result, err := s.db.Exec(deleteSQL, name)
 	if err != nil {
 		log.Printf("Error eliminando registros de estado para el sitio '%s': %v", name, err)
-		// Continuar con el guardado de la configuración aunque falle la eliminación de logs
+		// Continuar con el resto del historial aunque falle la eliminación de logs
 	} else {
 		// Obtener cuántos registros se eliminaron
 		rowsDeleted, err := result.RowsAffected()
@@ -631,11 +1032,17 @@ func (s *StatusPageService) RemoveSite(name string) error {
 		}
 	}
 
-	// Guardar la configuración actualizada
-	err = s.saveConfig()
-	if err != nil {
-		log.Printf("Error guardando configuración después de eliminar sitio '%s': %v", name, err)
-		return err
+	if _, err := s.db.Exec(`DELETE FROM incidents WHERE site_name = ?`, name); err != nil {
+		log.Printf("Error eliminando incidentes del sitio '%s': %v", name, err)
+	}
+	if _, err := s.db.Exec(`DELETE FROM alert_states WHERE site_name = ?`, name); err != nil {
+		log.Printf("Error eliminando estado de alertas del sitio '%s': %v", name, err)
+	}
+	if _, err := s.db.Exec(`DELETE FROM flapping_states WHERE site_name = ?`, name); err != nil {
+		log.Printf("Error eliminando oscilación del sitio '%s': %v", name, err)
+	}
+	if _, err := s.db.Exec(`DELETE FROM daily_stats WHERE site_name = ?`, name); err != nil {
+		log.Printf("Error eliminando estadísticas diarias del sitio '%s': %v", name, err)
 	}
 
 	log.Printf("Sitio '%s' eliminado exitosamente junto con su historial", name)
@@ -643,9 +1050,11 @@ func (s *StatusPageService) RemoveSite(name string) error {
 }
 
 func (s *StatusPageService) UpdateConfig(checkInterval, retentionDays int) error {
-	s.config.CheckInterval = checkInterval
-	s.config.RetentionDays = retentionDays
-	return s.saveConfig()
+	return s.updateConfig(func(c *Config) error {
+		c.CheckInterval = checkInterval
+		c.RetentionDays = retentionDays
+		return nil
+	})
 }
 
 func (s *StatusPageService) ManualCheck(siteName string) error {
@@ -655,9 +1064,9 @@ func (s *StatusPageService) ManualCheck(siteName string) error {
 		return nil
 	}
 
-	for _, site := range s.config.Sites {
+	for _, site := range s.currentConfig().Sites {
 		if site.Name == siteName {
-			go s.checkSite(site)
+			s.goTracked(func() { s.checkSite(site) })
 			return nil
 		}
 	}
@@ -691,12 +1100,13 @@ func (s *StatusPageService) WaitForInternetConnectivity(ctx context.Context) {
 
 // Método para iniciar el servicio con verificación de conectividad
 func (s *StatusPageService) StartWithConnectivityCheck(ctx context.Context) {
-	s.ctx = ctx
+	s.ctx, s.cancel = context.WithCancel(ctx)
 	log.Println("Iniciando servicio de monitoreo con verificación de conectividad...")
 
 	// Esperar hasta que haya conectividad a internet
-	s.WaitForInternetConnectivity(ctx)
+	s.WaitForInternetConnectivity(s.ctx)
 
 	// Iniciar monitoreo en background
-	go s.startMonitoring()
+	s.goTracked(s.startMonitoring)
+	s.startHTTPServer()
 }
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// Los cambios de configuración desde la API conviven con los lectores del
+// monitoreo; ejecutar con -race
+func TestConfigConcurrentUpdates(t *testing.T) {
+	s := newTestService(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				name := fmt.Sprintf("site-%d-%d", i, j)
+				if err := s.AddSite(name, "http://example.invalid", "GET", 5); err != nil {
+					t.Error(err)
+					return
+				}
+				if err := s.SetSiteSeverity(name, SeverityCritical); err != nil {
+					t.Error(err)
+					return
+				}
+				if j%2 == 0 {
+					if err := s.RemoveSite(name); err != nil {
+						t.Error(err)
+						return
+					}
+				}
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				for _, site := range s.currentConfig().Sites {
+					if site.URL == "" {
+						t.Errorf("sitio incompleto: %+v", site)
+					}
+				}
+				s.findSite("site-0-1")
+			}
+		}()
+	}
+	wg.Wait()
+
+	sites := s.currentConfig().Sites
+	if len(sites) != 40 {
+		t.Fatalf("sitios = %d, se esperaban 40", len(sites))
+	}
+	for _, site := range sites {
+		if site.Severity != SeverityCritical {
+			t.Errorf("%s: severidad %q", site.Name, site.Severity)
+		}
+	}
+}
+
+// Un cambio que no supera la validación no se publica
+func TestUpdateConfigRejectedChangeKeepsConfig(t *testing.T) {
+	s := newTestService(t)
+	for _, name := range []string{"a", "b"} {
+		if err := s.AddSite(name, "http://example.invalid", "GET", 5); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.SetSiteDependencies("a", []string{"b"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetSiteDependencies("b", []string{"a"}); err == nil {
+		t.Fatal("se esperaba un error por dependencia circular")
+	}
+
+	site, _ := s.findSite("b")
+	if len(site.DependsOn) != 0 {
+		t.Errorf("b.DependsOn = %v, se esperaba vacío", site.DependsOn)
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStatusPageIsPublic(t *testing.T) {
+	api := Site{Name: "api", Group: "Payments"}
+	admin := Site{Name: "admin", Group: "Internal"}
+	tests := []struct {
+		name   string
+		config StatusPageConfig
+		api    bool
+		admin  bool
+	}{
+		{"privados por defecto", StatusPageConfig{}, false, false},
+		{"por sitio", StatusPageConfig{PublicSites: []string{"api"}}, true, false},
+		{"por grupo", StatusPageConfig{PublicGroups: []string{"Payments"}}, true, false},
+		{"todos", StatusPageConfig{PublicSites: []string{"*"}}, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.config.isPublic(api); got != tt.api {
+				t.Errorf("api público = %v", got)
+			}
+			if got := tt.config.isPublic(admin); got != tt.admin {
+				t.Errorf("admin público = %v", got)
+			}
+		})
+	}
+}
+
+func TestPublicErrorMessage(t *testing.T) {
+	tests := []struct {
+		check StatusCheck
+		want  string
+	}{
+		{StatusCheck{Status: "down", StatusCode: 503}, publicErrorMessages["http_5xx"]},
+		{StatusCheck{Status: "down", StatusCode: 403}, publicErrorMessages["http_4xx"]},
+		{StatusCheck{Status: "down", ErrorMessage: `Get "http://10.0.0.12:8080/health": context deadline exceeded (Client.Timeout exceeded while awaiting headers)`}, publicErrorMessages["timeout"]},
+		{StatusCheck{Status: "down", ErrorMessage: "dial tcp: lookup db.internal.corp: no such host"}, publicErrorMessages["dns"]},
+		{StatusCheck{Status: "down", ErrorMessage: "dial tcp 10.0.0.12:443: connect: connection refused"}, publicErrorMessages["connection_refused"]},
+		{StatusCheck{Status: "down", ErrorMessage: "tls: failed to verify certificate: x509: certificate has expired"}, publicErrorMessages["tls"]},
+		{StatusCheck{Status: "unreachable", ErrorMessage: "unreachable (dependency down: vpn)"}, publicErrorMessages["dependency"]},
+		// Incidentes sin mensaje: la caída fue una respuesta HTTP de error
+		{StatusCheck{Status: "down"}, publicErrorMessages["http_5xx"]},
+		{StatusCheck{Status: "down", ErrorMessage: "stack trace en /srv/app/main.go:42"}, "El servicio no responde correctamente."},
+	}
+
+	for _, tt := range tests {
+		if got := publicErrorMessage(tt.check); got != tt.want {
+			t.Errorf("%+v: %q, se esperaba %q", tt.check, got, tt.want)
+		}
+	}
+}
+
+// La página pública no muestra sitios privados ni el error original
+func TestBuildStatusPageHidesPrivateDetails(t *testing.T) {
+	s := newTestService(t)
+	s.config.Sites = []Site{
+		{Name: "api", URL: "http://10.0.0.12:8080", Method: "GET", Timeout: 5, Group: "Payments"},
+		{Name: "admin", URL: "http://10.0.0.13", Method: "GET", Timeout: 5, Group: "Internal"},
+	}
+	s.config.StatusPage.PublicGroups = []string{"Payments"}
+
+	for _, site := range s.config.Sites {
+		s.saveStatusCheck(site, StatusCheck{SiteName: site.Name, SiteURL: site.URL, Status: "down",
+			ErrorMessage: "dial tcp 10.0.0.12:8080: connect: connection refused"})
+	}
+
+	data, err := s.buildStatusPage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data.Incidents) != 1 || data.Incidents[0].Site != "api" {
+		t.Fatalf("incidentes = %+v, se esperaba solo el de api", data.Incidents)
+	}
+	if msg := data.Incidents[0].Message; msg != publicErrorMessages["connection_refused"] {
+		t.Errorf("mensaje = %q", msg)
+	}
+	for _, group := range data.Groups {
+		for _, site := range group.Sites {
+			if site.Name == "admin" {
+				t.Error("el sitio privado aparece en la página")
+			}
+		}
+	}
+
+	s.config.StatusPage = StatusPageConfig{}
+	if data, err = s.buildStatusPage(); err != nil {
+		t.Fatal(err)
+	}
+	if len(data.Groups) != 0 || len(data.Incidents) != 0 || strings.Contains(data.Label, "operativos") {
+		t.Errorf("sin sitios públicos la página no debe mostrar nada: %+v", data)
+	}
+}
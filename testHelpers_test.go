@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestService crea un servicio con config.json y status.db en un
+// directorio temporal, sin sitios ni canales
+func newTestService(t *testing.T) *StatusPageService {
+	t.Helper()
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	retryDelay := notificationRetryDelay
+	notificationRetryDelay = time.Millisecond
+	t.Cleanup(func() { notificationRetryDelay = retryDelay })
+
+	s := NewStatusPageService()
+	t.Cleanup(func() {
+		s.inFlight.Wait()
+		s.db.Close()
+	})
+	s.config.Sites = nil
+	s.config.Notifications = nil
+	return s
+}
+
+// toggleServer responde 200 o 503 según el valor de up
+type toggleServer struct {
+	*httptest.Server
+	mu sync.Mutex
+	up bool
+}
+
+func newToggleServer(t *testing.T) *toggleServer {
+	t.Helper()
+	ts := &toggleServer{up: true}
+	ts.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ts.mu.Lock()
+		defer ts.mu.Unlock()
+		if !ts.up {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func (ts *toggleServer) set(up bool) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	ts.up = up
+}
+
+// webhookRecorder guarda los cuerpos JSON recibidos por un webhook de prueba
+type webhookRecorder struct {
+	*httptest.Server
+	mu       sync.Mutex
+	payloads []webhookPayload
+	headers  []http.Header
+}
+
+func newWebhookRecorder(t *testing.T) *webhookRecorder {
+	t.Helper()
+	rec := &webhookRecorder{}
+	rec.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var payload webhookPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Errorf("cuerpo del webhook inválido: %v: %s", err, body)
+		}
+		rec.mu.Lock()
+		defer rec.mu.Unlock()
+		rec.payloads = append(rec.payloads, payload)
+		rec.headers = append(rec.headers, r.Header.Clone())
+	}))
+	t.Cleanup(rec.Close)
+	return rec
+}
+
+// take devuelve y descarta los cuerpos recibidos
+func (rec *webhookRecorder) take() []webhookPayload {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	payloads := rec.payloads
+	rec.payloads, rec.headers = nil, nil
+	return payloads
+}
+
+// testEvent es un evento de ejemplo sobre un incidente de 5 minutos
+func testEvent(eventType string) NotificationEvent {
+	started := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	resolved := started.Add(5 * time.Minute)
+	return NotificationEvent{
+		Type:       eventType,
+		Site:       Site{Name: "api", URL: "https://api.example.com", Group: "Payments"},
+		Check:      StatusCheck{Status: "down", StatusCode: 503, ResponseTime: 120, ErrorMessage: "HTTP 503"},
+		Incident:   Incident{ID: 42, SiteName: "api", StartedAt: started, ResolvedAt: &resolved},
+		OccurredAt: resolved,
+	}
+}
+
+// captureServer guarda la última petición recibida
+type captureServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	captured capturedRequest
+}
+
+type capturedRequest struct {
+	method string
+	path   string
+	header http.Header
+	body   []byte
+}
+
+func newCaptureServer(t *testing.T) *captureServer {
+	t.Helper()
+	cs := &captureServer{}
+	cs.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		cs.mu.Lock()
+		defer cs.mu.Unlock()
+		cs.captured = capturedRequest{method: r.Method, path: r.URL.Path, header: r.Header.Clone(), body: body}
+	}))
+	t.Cleanup(cs.Close)
+	return cs
+}
+
+func (cs *captureServer) last() capturedRequest {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	return cs.captured
+}
+
+// sendCaptured envía el evento por el canal, que debe apuntar a ts, y
+// devuelve el cuerpo JSON decodificado junto con la petición
+func sendCaptured(t *testing.T, ts *captureServer, channel NotificationChannel, event NotificationEvent) (map[string]any, capturedRequest) {
+	t.Helper()
+	notifier, err := newNotifier(channel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := notifier.Send(context.Background(), event); err != nil {
+		t.Fatal(err)
+	}
+	captured := ts.last()
+	var payload map[string]any
+	if err := json.Unmarshal(captured.body, &payload); err != nil {
+		t.Fatalf("cuerpo JSON inválido: %v: %s", err, captured.body)
+	}
+	return payload, captured
+}
+
+// jsonPath recorre un JSON decodificado con una ruta "a.0.b"
+func jsonPath(v any, path string) any {
+	for _, key := range strings.Split(path, ".") {
+		switch node := v.(type) {
+		case map[string]any:
+			v = node[key]
+		case []any:
+			i, err := strconv.Atoi(key)
+			if err != nil || i >= len(node) {
+				return nil
+			}
+			v = node[i]
+		default:
+			return nil
+		}
+	}
+	return v
+}
+
+// checkJSONPaths compara los valores de las rutas indicadas
+func checkJSONPaths(t *testing.T, payload map[string]any, want map[string]any) {
+	t.Helper()
+	for path, value := range want {
+		if got := jsonPath(payload, path); fmt.Sprint(got) != fmt.Sprint(value) {
+			t.Errorf("%s = %v, se esperaba %v", path, got, value)
+		}
+	}
+}
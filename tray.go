@@ -0,0 +1,150 @@
+//go:build !headless
+
+package main
+
+import (
+	"log"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v3/pkg/application"
+	"github.com/wailsapp/wails/v3/pkg/icons"
+)
+
+// Evento enviado al frontend para abrir el detalle de un sitio
+const eventSelectSite = "site:select"
+
+// setupSystemTray crea el icono de bandeja y lo mantiene actualizado con el
+// estado agregado de los sitios
+func setupSystemTray(app *application.App, window *application.WebviewWindow, statusService *StatusPageService) *application.SystemTray {
+	systemTray := app.NewSystemTray()
+	setDefaultTrayIcon(systemTray)
+
+	var mu sync.Mutex
+	lastState := ""
+	var refresh func()
+	refresh = func() {
+		mu.Lock()
+		defer mu.Unlock()
+
+		sites, err := statusService.GetAllSites("")
+		if err != nil {
+			log.Printf("Error actualizando bandeja del sistema: %v", err)
+			return
+		}
+
+		status := computeTrayStatus(sites)
+		if status.State != lastState {
+			if status.State == "ok" || status.State == "unknown" {
+				setDefaultTrayIcon(systemTray)
+			} else {
+				systemTray.SetIcon(trayIcon(status.State))
+				systemTray.SetDarkModeIcon(trayIcon(status.State))
+			}
+			lastState = status.State
+		}
+
+		// En Linux la etiqueta se usa como título del icono; en macOS se omite
+		// porque ocuparía la barra de menús junto al icono (Windows la ignora)
+		if runtime.GOOS != "darwin" {
+			systemTray.SetLabel(status.Tooltip)
+		}
+		systemTray.SetMenu(buildTrayMenu(app, window, statusService, status, refresh))
+	}
+
+	// Los métodos de SystemTray ya se ejecutan en el hilo principal
+	refresh()
+	statusService.OnTransition(func(NotificationEvent) {
+		refresh()
+	})
+
+	// Refresco periódico hasta que se detenga el servicio
+	var done <-chan struct{}
+	if statusService.ctx != nil {
+		done = statusService.ctx.Done()
+	}
+	go func() {
+		ticker := time.NewTicker(time.Duration(statusService.GetConfig().CheckInterval) * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				refresh()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	systemTray.AttachWindow(window).WindowOffset(5)
+	return systemTray
+}
+
+func setDefaultTrayIcon(systemTray *application.SystemTray) {
+	// Support for template icons on macOS
+	if runtime.GOOS == "darwin" {
+		systemTray.SetTemplateIcon(icons.SystrayMacTemplate)
+	} else {
+		// Support for light/dark mode icons
+		systemTray.SetDarkModeIcon(icons.SystrayDark)
+		systemTray.SetIcon(icons.SystrayLight)
+	}
+}
+
+func buildTrayMenu(app *application.App, window *application.WebviewWindow, statusService *StatusPageService, status TrayStatus, refresh func()) *application.Menu {
+	menu := app.NewMenu()
+
+	menu.Add(status.Tooltip).SetEnabled(false)
+	for _, siteName := range status.DownSites {
+		siteName := siteName
+		submenu := menu.AddSubmenu("🔴 " + siteName)
+		submenu.Add("Ver detalle").OnClick(func(_ *application.Context) {
+			window.Show()
+			window.Focus()
+			app.EmitEvent(eventSelectSite, siteName)
+		})
+
+		incident, err := statusService.openIncident(siteName)
+		if err != nil || incident == nil {
+			continue
+		}
+		if incident.AcknowledgedAt != nil {
+			submenu.Add("Caída reconocida").SetEnabled(false)
+			continue
+		}
+		incidentID := incident.ID
+		submenu.Add("Reconocer caída").OnClick(func(_ *application.Context) {
+			if err := statusService.AcknowledgeIncident(incidentID); err != nil {
+				log.Printf("Error reconociendo incidente: %v", err)
+			}
+			go refresh()
+		})
+	}
+	for _, siteName := range status.UnreachableSites {
+		siteName := siteName
+		menu.Add("⚪ " + siteName + " (dependencia caída)").OnClick(func(_ *application.Context) {
+			window.Show()
+			window.Focus()
+			app.EmitEvent(eventSelectSite, siteName)
+		})
+	}
+	menu.AddSeparator()
+
+	menu.Add("Mostrar StatusPage").OnClick(func(_ *application.Context) {
+		window.Show()
+		window.Focus()
+	})
+	menu.AddSeparator()
+	menu.AddCheckbox("No molestar", statusService.GetDoNotDisturb()).OnClick(func(ctx *application.Context) {
+		if err := statusService.SetDoNotDisturb(ctx.IsChecked()); err != nil {
+			log.Printf("Error guardando modo no molestar: %v", err)
+		}
+	})
+	menu.AddSeparator()
+	menu.Add("Salir").OnClick(func(_ *application.Context) {
+		app.Quit()
+	})
+
+	return menu
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"sync"
+)
+
+// Estado agregado de todos los sitios mostrado en la bandeja del sistema
+type TrayStatus struct {
+	State            string   `json:"state"` // "ok", "degraded", "down", "unknown"
+	Total            int      `json:"total"`
+	Down             int      `json:"down"`
+	DownSites        []string `json:"downSites"`
+	Unreachable      int      `json:"unreachable"`
+	UnreachableSites []string `json:"unreachableSites"`
+	Tooltip          string   `json:"tooltip"`
+}
+
+// computeTrayStatus calcula el estado agregado a partir del último estado de
+// cada sitio. Los sitios "unreachable" se informan aparte y no cambian el
+// color: dependen de un sitio caído, que ya cuenta como caída.
+func computeTrayStatus(sites []SiteDetail) TrayStatus {
+	status := TrayStatus{Total: len(sites), DownSites: []string{}, UnreachableSites: []string{}}
+
+	known := 0
+	for _, site := range sites {
+		switch site.Status {
+		case "up":
+			known++
+		case "down":
+			known++
+			status.Down++
+			status.DownSites = append(status.DownSites, site.Name)
+		case "unreachable":
+			known++
+			status.Unreachable++
+			status.UnreachableSites = append(status.UnreachableSites, site.Name)
+		}
+	}
+
+	switch {
+	case known == 0:
+		status.State = "unknown"
+	case status.Down == 0:
+		status.State = "ok"
+	case status.Down == status.Total:
+		status.State = "down"
+	default:
+		status.State = "degraded"
+	}
+
+	switch {
+	case status.State == "unknown":
+		status.Tooltip = "StatusPage Monitor: sin datos"
+	case status.Down == 0 && status.Unreachable == 0:
+		status.Tooltip = fmt.Sprintf("StatusPage Monitor: %d/%d sitios operativos", status.Total, status.Total)
+	case status.Down == 0:
+		status.Tooltip = fmt.Sprintf("StatusPage Monitor: %d/%d sitios no alcanzables", status.Unreachable, status.Total)
+	default:
+		status.Tooltip = fmt.Sprintf("StatusPage Monitor: %d/%d sitios caídos", status.Down, status.Total)
+		if status.Unreachable > 0 {
+			status.Tooltip += fmt.Sprintf(", %d no alcanzables", status.Unreachable)
+		}
+	}
+
+	return status
+}
+
+var (
+	trayIconsOnce sync.Once
+	trayIcons     map[string][]byte
+)
+
+// trayIcon devuelve el icono PNG (círculo de color) para "degraded" o "down";
+// los estados "ok" y "unknown" usan el icono por defecto de la aplicación
+func trayIcon(state string) []byte {
+	trayIconsOnce.Do(func() {
+		trayIcons = map[string][]byte{
+			"degraded": renderStatusIcon(color.RGBA{245, 158, 11, 255}),
+			"down":     renderStatusIcon(color.RGBA{239, 68, 68, 255}),
+		}
+	})
+	if icon, ok := trayIcons[state]; ok {
+		return icon
+	}
+	return trayIcons["down"]
+}
+
+func renderStatusIcon(fill color.RGBA) []byte {
+	const size = 32
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+
+	center := float64(size-1) / 2
+	radius := float64(size)/2 - 2
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			dx, dy := float64(x)-center, float64(y)-center
+			if dx*dx+dy*dy <= radius*radius {
+				img.Set(x, y, fill)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	png.Encode(&buf, img)
+	return buf.Bytes()
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestComputeTrayStatus(t *testing.T) {
+	sites := func(statuses ...string) []SiteDetail {
+		var details []SiteDetail
+		for i, status := range statuses {
+			details = append(details, SiteDetail{Name: string(rune('a' + i)), Status: status})
+		}
+		return details
+	}
+
+	tests := []struct {
+		name             string
+		sites            []SiteDetail
+		state            string
+		down             int
+		downSites        string
+		unreachableSites string
+		tooltip          string
+	}{
+		{"sin sitios", nil, "unknown", 0, "", "", "StatusPage Monitor: sin datos"},
+		{"sin checks", sites("unknown", "unknown"), "unknown", 0, "", "", "StatusPage Monitor: sin datos"},
+		{"todos operativos", sites("up", "up", "up"), "ok", 0, "", "", "StatusPage Monitor: 3/3 sitios operativos"},
+		{"uno caído", sites("up", "down", "up"), "degraded", 1, "b", "", "StatusPage Monitor: 1/3 sitios caídos"},
+		{"no alcanzables aparte", sites("down", "unreachable", "unreachable"), "degraded", 1, "a", "b,c", "StatusPage Monitor: 1/3 sitios caídos, 2 no alcanzables"},
+		{"solo no alcanzables", sites("up", "unreachable"), "ok", 0, "", "b", "StatusPage Monitor: 1/2 sitios no alcanzables"},
+		{"todos caídos", sites("down", "down"), "down", 2, "a,b", "", "StatusPage Monitor: 2/2 sitios caídos"},
+		{"caídos y sin datos", sites("down", "unknown"), "degraded", 1, "a", "", "StatusPage Monitor: 1/2 sitios caídos"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := computeTrayStatus(tt.sites)
+			if status.State != tt.state || status.Total != len(tt.sites) || status.Down != tt.down ||
+				strings.Join(status.DownSites, ",") != tt.downSites || status.Tooltip != tt.tooltip {
+				t.Errorf("estado = %+v", status)
+			}
+			if strings.Join(status.UnreachableSites, ",") != tt.unreachableSites || status.Unreachable != len(status.UnreachableSites) {
+				t.Errorf("no alcanzables = %d %q, se esperaba %q", status.Unreachable, status.UnreachableSites, tt.unreachableSites)
+			}
+			if status.DownSites == nil || status.UnreachableSites == nil {
+				t.Error("DownSites y UnreachableSites deben ser listas vacías, no nil, para el frontend")
+			}
+		})
+	}
+}
+
+func TestTrayIcon(t *testing.T) {
+	for _, state := range []string{"degraded", "down"} {
+		img, err := png.Decode(bytes.NewReader(trayIcon(state)))
+		if err != nil {
+			t.Fatalf("%s: %v", state, err)
+		}
+		if size := img.Bounds().Size(); size.X != 32 || size.Y != 32 {
+			t.Errorf("%s: tamaño %v", state, size)
+		}
+	}
+	if bytes.Equal(trayIcon("degraded"), trayIcon("down")) {
+		t.Error("los iconos de degradado y caído deben ser distintos")
+	}
+}
+
+// El modo "no molestar" del menú de la bandeja se guarda en config.json y
+// silencia las notificaciones de escritorio
+func TestDoNotDisturb(t *testing.T) {
+	s := newTestService(t)
+	if err := s.AddSite("api", "https://api.example.com", "GET", 5); err != nil {
+		t.Fatal(err)
+	}
+	event := NotificationEvent{Type: EventDown, Site: Site{Name: "api"}}
+
+	if s.GetDoNotDisturb() || !s.desktopNotificationAllowed(event) {
+		t.Fatal("las notificaciones deben estar permitidas por defecto")
+	}
+
+	if err := s.SetDoNotDisturb(true); err != nil {
+		t.Fatal(err)
+	}
+	if !s.GetDoNotDisturb() || s.desktopNotificationAllowed(event) {
+		t.Error("con no molestar activo no debe mostrarse la notificación")
+	}
+	reloaded := &StatusPageService{}
+	if err := reloaded.loadConfig(); err != nil {
+		t.Fatal(err)
+	}
+	if !reloaded.GetDoNotDisturb() {
+		t.Error("no molestar no se guardó en config.json")
+	}
+
+	if err := s.SetDoNotDisturb(false); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetSiteDesktopMute("api", true); err != nil {
+		t.Fatal(err)
+	}
+	if s.desktopNotificationAllowed(event) {
+		t.Error("un sitio silenciado no debe mostrar notificaciones")
+	}
+}
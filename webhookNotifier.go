@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Configuración de un canal webhook genérico
+type WebhookConfig struct {
+	URL     string            `json:"url"`
+	Method  string            `json:"method,omitempty"` // POST por defecto
+	Headers map[string]string `json:"headers,omitempty"`
+	// Plantilla text/template del cuerpo JSON. Recibe los campos del
+	// NotificationEvent, .Title y .Message ya renderizados, y dispone de la
+	// función "json" para escapar valores.
+	Template string `json:"template,omitempty"`
+}
+
+type WebhookNotifier struct {
+	config    WebhookConfig
+	templates *messageTemplates
+}
+
+// Datos de la plantilla del cuerpo JSON
+type webhookTemplateData struct {
+	notificationTemplateData
+	Message string
+}
+
+// Cuerpo enviado cuando el webhook no define plantilla
+type webhookPayload struct {
+	Event           string     `json:"event"`
+	Site            string     `json:"site"`
+	URL             string     `json:"url"`
+	Group           string     `json:"group,omitempty"`
+	Status          string     `json:"status"`
+	StatusCode      int        `json:"statusCode"`
+	ResponseTime    int64      `json:"responseTime"`
+	ErrorMessage    string     `json:"errorMessage,omitempty"`
+	IncidentID      int64      `json:"incidentId"`
+	DurationSeconds int64      `json:"durationSeconds"`
+	Title           string     `json:"title"`
+	Message         string     `json:"message"`
+	Alert           *AlertInfo `json:"alert,omitempty"`
+	OccurredAt      string     `json:"occurredAt"`
+}
+
+var webhookTemplateFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+func (w *WebhookNotifier) render(event NotificationEvent) ([]byte, error) {
+	rendered, err := w.templates.render(event)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(w.config.Template) == "" {
+		return json.Marshal(webhookPayload{
+			Event:           event.Type,
+			Site:            event.Site.Name,
+			URL:             event.Site.URL,
+			Group:           event.Site.Group,
+			Status:          event.Check.Status,
+			StatusCode:      event.Check.StatusCode,
+			ResponseTime:    event.Check.ResponseTime,
+			ErrorMessage:    event.Check.ErrorMessage,
+			IncidentID:      event.Incident.ID,
+			DurationSeconds: int64(event.Duration().Seconds()),
+			Title:           rendered.Title,
+			Message:         rendered.Message,
+			Alert:           event.Alert,
+			OccurredAt:      event.OccurredAt.Format(time.RFC3339),
+		})
+	}
+
+	tmpl, err := template.New("webhook").Funcs(webhookTemplateFuncs).Parse(w.config.Template)
+	if err != nil {
+		return nil, fmt.Errorf("plantilla webhook inválida: %w", err)
+	}
+
+	data := webhookTemplateData{
+		notificationTemplateData: notificationTemplateData{
+			NotificationEvent: event,
+			Duration:          event.Duration(),
+			Facts:             eventFacts(event),
+			Title:             rendered.Title,
+		},
+		Message: rendered.Message,
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("error ejecutando plantilla webhook: %w", err)
+	}
+
+	if !json.Valid(buf.Bytes()) {
+		return nil, fmt.Errorf("la plantilla webhook no genera un JSON válido")
+	}
+	return buf.Bytes(), nil
+}
+
+func (w *WebhookNotifier) Send(ctx context.Context, event NotificationEvent) (string, error) {
+	body, err := w.render(event)
+	if err != nil {
+		return "", &notificationRenderError{err}
+	}
+
+	method := w.config.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+
+	headers := map[string]string{"Content-Type": "application/json"}
+	for k, v := range w.config.Headers {
+		headers[k] = v
+	}
+
+	return string(body), postNotification(ctx, method, w.config.URL, headers, body)
+}
+
+// notificationHTTPError es una respuesta no exitosa de un canal HTTP
+type notificationHTTPError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *notificationHTTPError) Error() string {
+	return fmt.Sprintf("respuesta HTTP %d: %s", e.StatusCode, e.Body)
+}
+
+// postNotification envía el cuerpo por HTTP y devuelve error si la respuesta no es 2xx
+func postNotification(ctx context.Context, method, url string, headers map[string]string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := notificationHTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return &notificationHTTPError{StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(msg))}
+	}
+
+	// Vaciar el cuerpo para reutilizar la conexión
+	io.Copy(io.Discard, resp.Body)
+	return nil
+}
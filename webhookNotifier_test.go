@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestWebhookNotifierDefaultPayload(t *testing.T) {
+	ts := newCaptureServer(t)
+	notifier, err := newNotifier(NotificationChannel{Name: "hook", Type: "webhook", Webhook: &WebhookConfig{
+		URL:     ts.URL,
+		Method:  http.MethodPut,
+		Headers: map[string]string{"Authorization": "Bearer secreto", "X-Source": "status-page"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sent, err := notifier.Send(context.Background(), testEvent(EventDown))
+	if err != nil {
+		t.Fatal(err)
+	}
+	captured := ts.last()
+	if sent != string(captured.body) {
+		t.Errorf("el payload registrado no coincide con el enviado:\n%s\n%s", sent, captured.body)
+	}
+	if captured.method != http.MethodPut {
+		t.Errorf("método = %s", captured.method)
+	}
+	for key, want := range map[string]string{
+		"Content-Type":  "application/json",
+		"Authorization": "Bearer secreto",
+		"X-Source":      "status-page",
+	} {
+		if got := captured.header.Get(key); got != want {
+			t.Errorf("%s = %q, se esperaba %q", key, got, want)
+		}
+	}
+
+	var payload webhookPayload
+	if err := json.Unmarshal(captured.body, &payload); err != nil {
+		t.Fatal(err)
+	}
+	if payload.Event != EventDown || payload.Site != "api" || payload.URL != "https://api.example.com" ||
+		payload.Group != "Payments" || payload.Status != "down" || payload.StatusCode != 503 ||
+		payload.IncidentID != 42 || payload.DurationSeconds != 300 || payload.Title == "" ||
+		payload.Message == "" || payload.OccurredAt != "2024-05-01T10:05:00Z" {
+		t.Errorf("payload = %+v", payload)
+	}
+}
+
+func TestWebhookNotifierTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     string
+		wantErr  bool
+	}{
+		{"campos del evento", `{"text": {{json .Site.Name}}, "code": {{.Check.StatusCode}}}`, `{"text": "api", "code": 503}`, false},
+		{"escapa valores", `{"error": {{json .Check.ErrorMessage}}}`, `{"error": "HTTP 503"}`, false},
+		{"JSON inválido", `{"text": {{.Site.Name}}}`, "", true},
+		{"plantilla inválida", `{{.Site.Name`, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newCaptureServer(t)
+			notifier, err := newNotifier(NotificationChannel{Name: "hook", Type: "webhook",
+				Webhook: &WebhookConfig{URL: ts.URL, Template: tt.template}})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = notifier.Send(context.Background(), testEvent(EventDown))
+			captured := ts.last()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("se esperaba un error")
+				}
+				if captured.body != nil {
+					t.Error("no debe enviarse un cuerpo inválido")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(captured.body) != tt.want {
+				t.Errorf("cuerpo = %s, se esperaba %s", captured.body, tt.want)
+			}
+		})
+	}
+}